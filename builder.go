@@ -6,6 +6,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/tarantool/go-config/keypath"
 	"github.com/tarantool/go-config/tree"
 	"github.com/tarantool/go-config/validator"
 	"github.com/tarantool/go-config/validators/jsonschema"
@@ -27,6 +28,10 @@ type Builder struct {
 	inheritances []inheritanceConfig
 	// Merger defines how values are merged into the configuration tree.
 	merger Merger
+	// interpolation, when set, enables expansion of ${...} references.
+	interpolation *interpolationConfig
+	// sensitive holds the patterns of keys whose values are masked in output.
+	sensitive []keypath.KeyPath
 }
 
 // NewBuilder creates a new instance of Builder.
@@ -37,6 +42,8 @@ func NewBuilder() Builder {
 		skipValidation: false,
 		inheritances:   nil,
 		merger:         nil,
+		interpolation:  nil,
+		sensitive:      nil,
 	}
 }
 
@@ -117,6 +124,31 @@ func (b *Builder) WithMerger(merger Merger) Builder {
 	return *b
 }
 
+// WithInterpolation enables expansion of references in string values:
+//
+//   - ${env:NAME} is replaced with the environment variable NAME;
+//   - ${file:/path} is replaced with the contents of the file, without a
+//     trailing newline (e.g., a mounted secret);
+//   - ${path:other/key} is replaced with the value of another key. When the
+//     reference is the whole value, the referenced value keeps its type.
+//
+// A reference can be embedded in text ("http://${env:HOST}:8080"); "$${"
+// escapes a literal "${". Other schemes can be added, and "env" and "file"
+// replaced, with [WithInterpolationResolver].
+//
+// Interpolation runs during Build, after collectors are merged and before
+// validation, so the validator sees the expanded values. An unresolved
+// reference, a reference cycle or a malformed reference fails the build with
+// an *[InterpolationError] that carries the location of the value.
+//
+// Expanded values remember their source text: [Config.MarshalYAML] writes
+// the references back rather than their expansions, so a round-tripped file
+// does not leak secrets.
+func (b *Builder) WithInterpolation(opts ...InterpolationOption) Builder {
+	b.interpolation = newInterpolationConfig(opts)
+	return *b
+}
+
 // WithInheritance registers a hierarchy for inheritance resolution.
 // Multiple hierarchies can be registered (e.g., groups and buckets).
 //
@@ -194,8 +226,17 @@ func (b *Builder) Build(ctx context.Context) (Config, []error) {
 		return newConfig(nil, nil, nil), errs
 	}
 
+	if b.interpolation != nil {
+		errs = interpolate(b.interpolation, root, layers)
+		if len(errs) > 0 {
+			return newConfig(nil, nil, nil), errs
+		}
+	}
+
 	if b.validator != nil && !b.skipValidation {
 		validationErrs := b.validator.Validate(root)
+		redactValidationErrors(b.sensitive, root, validationErrs)
+
 		for i := range validationErrs {
 			errs = append(errs, &validationErrs[i])
 		}
@@ -205,7 +246,10 @@ func (b *Builder) Build(ctx context.Context) (Config, []error) {
 		return newConfig(nil, nil, nil), errs
 	}
 
-	return newLayeredConfig(root, layers, b.inheritances, b.validator), nil
+	cfg := newLayeredConfig(root, layers, b.inheritances, b.validator)
+	cfg.sensitive = b.sensitive
+
+	return cfg, nil
 }
 
 // buildLayer merges one top-level collector into a fresh layer tree. For a
@@ -36,3 +36,68 @@ type MultiCollector interface {
 	// merged in order (earlier = lower priority).
 	Collectors(ctx context.Context) ([]Collector, error)
 }
+
+// StaleCollector is an optional interface that a Collector may implement to
+// report that it serves cached data because its source could not be read.
+// Values merged from a stale collector have MetaInfo.Stale set.
+type StaleCollector interface {
+	// Stale returns true if the values of the last Read come from a cache.
+	Stale() bool
+}
+
+// KeyEventType classifies the change of a single key reported in a
+// [WatchEvent].
+type KeyEventType int
+
+const (
+	// KeyPut indicates a key that was created or updated.
+	KeyPut KeyEventType = iota
+	// KeyDelete indicates a key that was deleted.
+	KeyDelete
+)
+
+// String returns a human-readable name of the key event type.
+func (t KeyEventType) String() string {
+	switch t {
+	case KeyPut:
+		return "put"
+	case KeyDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyEvent describes the change of a single key of a collector's backend.
+type KeyEvent struct {
+	// Key is the changed key (e.g., the storage key of a document).
+	Key string
+	// Type is the kind of change.
+	Type KeyEventType
+	// Revision is the revision of the key after the change; empty for
+	// KeyDelete or when unknown.
+	Revision RevisionType
+}
+
+// WatchEvent represents a change notification from a collector's backend.
+type WatchEvent struct {
+	// Prefix indicates the key or prefix that was changed.
+	Prefix string
+	// Keys lists the changed keys under Prefix, if the collector can tell.
+	// Empty means that anything under Prefix may have changed. A [Reloader]
+	// rebuilds the whole configuration either way.
+	Keys []KeyEvent
+	// Revision is the revision of the collector after the change; empty when
+	// unknown.
+	Revision RevisionType
+}
+
+// Watcher is an optional interface that a Collector may implement to provide
+// reactive change notifications from its backend (e.g., a storage prefix).
+// A [Reloader] subscribes to every top-level collector implementing Watcher
+// and rebuilds the configuration when any of them reports a change.
+type Watcher interface {
+	// Watch returns a channel that streams change events for the collector's
+	// key or prefix. The channel is closed when the context is cancelled.
+	Watch(ctx context.Context) (<-chan WatchEvent, error)
+}
@@ -0,0 +1,735 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultCacheRetryInterval is the interval at which [Cache.Watch] checks
+// whether the source of a stale cache can be read again.
+const DefaultCacheRetryInterval = 5 * time.Second
+
+// Cache wraps a collector, typically a [Storage] collector, with a
+// last-known-good copy of its documents persisted in a local file.
+//
+// Every successful read of the collector is written to the file, with the
+// name, source type and revision of every document and the source name of
+// every value. When the collector fails, i.e. a [config.MultiCollector]
+// returns an error from Collectors, the documents of the file are served
+// instead: a configuration can still be built while the backend is
+// unreachable, even right after a restart. Values served from the file have
+// MetaInfo.Stale set. The next successful read replaces them.
+//
+// A plain [config.Collector] cannot report read failures, so for one the
+// cache only keeps a copy of the values it read.
+//
+// Cache implements [Watcher]: it forwards the events of the wrapped
+// collector, if it is a Watcher, and while the cache is stale it reports an
+// event as soon as the collector can be read again, so that a
+// [config.Reloader] refreshes the configuration.
+type Cache struct {
+	collector config.Collector
+	path      string
+	interval  time.Duration
+
+	// readMu serializes the reads of the collector by Collectors and by
+	// the recovery probe of Watch, so that probed is never older than the
+	// last read.
+	readMu sync.Mutex
+
+	mu       sync.RWMutex
+	stale    bool
+	revision config.RevisionType
+	err      error
+	// probed holds the documents read by a successful recovery probe of
+	// Watch, served by the next Collectors call.
+	probed []config.Collector
+}
+
+// cacheFile is the format of the cache file.
+type cacheFile struct {
+	Revision  config.RevisionType `yaml:"revision"`
+	Documents []cacheDocument     `yaml:"documents"`
+}
+
+// cacheDocument is a document of a collector stored in the cache file.
+type cacheDocument struct {
+	Name      string              `yaml:"name"`
+	Source    cacheSourceType     `yaml:"source"`
+	Revision  config.RevisionType `yaml:"revision"`
+	KeepOrder bool                `yaml:"keepOrder"`
+	Values    []cacheValue        `yaml:"values"`
+}
+
+// cacheValue is a value of a document stored in the cache file. Numbers are
+// tagged with their type, so that they are read back as they were read: an
+// integral float is not read back as an integer, nor an int64 as an int.
+type cacheValue struct {
+	Path   []string  `yaml:"path"`
+	Source string    `yaml:"source,omitempty"`
+	Value  yaml.Node `yaml:"value"`
+}
+
+// cacheSourceType is a source type stored in the cache file by name.
+type cacheSourceType config.SourceType
+
+// cacheSourceTypeName returns the name of a source type in the cache file,
+// or "" for a source type without a name.
+func cacheSourceTypeName(source config.SourceType) string {
+	switch source {
+	case config.UnknownSource:
+		return "unknown"
+	case config.EnvDefaultSource:
+		return "env-default"
+	case config.StorageSource:
+		return "storage"
+	case config.FileSource:
+		return "file"
+	case config.EnvSource:
+		return "env"
+	case config.ModifiedSource:
+		return "modified"
+	case config.FlagSource:
+		return "flag"
+	case config.FlagDefaultSource:
+		return "flag-default"
+	case config.HTTPSource:
+		return "http"
+	default:
+		return ""
+	}
+}
+
+// MarshalYAML writes the name of the source type, or its number if it has
+// no name.
+func (t cacheSourceType) MarshalYAML() (any, error) {
+	if name := cacheSourceTypeName(config.SourceType(t)); name != "" {
+		return name, nil
+	}
+
+	return int(t), nil
+}
+
+// UnmarshalYAML reads a source type written by MarshalYAML.
+func (t *cacheSourceType) UnmarshalYAML(node *yaml.Node) error {
+	for source := config.UnknownSource; source <= config.HTTPSource; source++ {
+		if node.Value == cacheSourceTypeName(source) {
+			*t = cacheSourceType(source)
+			return nil
+		}
+	}
+
+	number, err := strconv.Atoi(node.Value)
+	if err != nil {
+		return fmt.Errorf("%w: line %d: unknown source type %q", ErrCache, node.Line, node.Value)
+	}
+
+	*t = cacheSourceType(number)
+
+	return nil
+}
+
+// NewCache creates a new Cache of the collector, persisted in the file at
+// path. The directory of the file must exist.
+func NewCache(collector config.Collector, path string) *Cache {
+	return &Cache{
+		collector: collector,
+		path:      path,
+		interval:  DefaultCacheRetryInterval,
+		readMu:    sync.Mutex{},
+		mu:        sync.RWMutex{},
+		stale:     false,
+		revision:  "",
+		err:       nil,
+		probed:    nil,
+	}
+}
+
+// WithRetryInterval sets the interval at which [Cache.Watch] checks whether
+// the collector can be read again while the cache is stale (default
+// [DefaultCacheRetryInterval]). A non-positive interval restores the
+// default.
+func (c *Cache) WithRetryInterval(interval time.Duration) *Cache {
+	if interval <= 0 {
+		interval = DefaultCacheRetryInterval
+	}
+
+	c.interval = interval
+
+	return c
+}
+
+// Name returns the name of the wrapped collector.
+func (c *Cache) Name() string {
+	return c.collector.Name()
+}
+
+// Source returns the source type of the wrapped collector.
+func (c *Cache) Source() config.SourceType {
+	return c.collector.Source()
+}
+
+// Revision returns the revision of the last successful read of the wrapped
+// collector, or the revision stored in the cache file while it is served.
+func (c *Cache) Revision() config.RevisionType {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.revision
+}
+
+// KeepOrder returns whether the wrapped collector preserves key order.
+func (c *Cache) KeepOrder() bool {
+	return c.collector.KeepOrder()
+}
+
+// Stale returns true if the last Collectors call served the cache file
+// because the wrapped collector failed.
+func (c *Cache) Stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stale
+}
+
+// Err returns the error of the wrapped collector while the cache file is
+// served, or the error of writing the cache file after a successful read.
+// It returns nil otherwise.
+func (c *Cache) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.err
+}
+
+// Collectors implements config.MultiCollector. It reads the wrapped
+// collector and returns one sub-collector per document, saving them to the
+// cache file. If the collector fails, the documents of the cache file are
+// returned instead, marked as stale; if there is no cache file either, the
+// error of the collector is returned. A failure to write the cache file does
+// not fail Collectors and is reported by [Cache.Err].
+func (c *Cache) Collectors(ctx context.Context) ([]config.Collector, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	docs, values, err := c.read(ctx)
+	if err == nil {
+		saveErr := c.save(docs)
+
+		c.mu.Lock()
+		c.stale, c.revision, c.err = false, c.collector.Revision(), saveErr
+		c.mu.Unlock()
+
+		subs := make([]config.Collector, 0, len(docs))
+		for i, doc := range docs {
+			subs = append(subs, &cachedDocument{doc: doc, values: values[i], stale: false})
+		}
+
+		return subs, nil
+	}
+
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	cached, loadErr := c.load()
+	if loadErr != nil {
+		if errors.Is(loadErr, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w; %w", err, loadErr)
+	}
+
+	c.mu.Lock()
+	c.stale, c.revision, c.err = true, cached.Revision, err
+	c.mu.Unlock()
+
+	subs := make([]config.Collector, 0, len(cached.Documents))
+	for _, doc := range cached.Documents {
+		subs = append(subs, &cachedDocument{doc: doc, values: cachedValues(doc), stale: true})
+	}
+
+	return subs, nil
+}
+
+// Read emits the values of all documents returned by Collectors on a single
+// channel. The Builder uses Collectors instead.
+func (c *Cache) Read(ctx context.Context) <-chan config.Value {
+	valueChan := make(chan config.Value)
+
+	go func() {
+		defer close(valueChan)
+
+		subs, err := c.Collectors(ctx)
+		if err != nil {
+			return
+		}
+
+		for _, sub := range subs {
+			for val := range sub.Read(ctx) {
+				select {
+				case <-ctx.Done():
+					return
+				case valueChan <- val:
+				}
+			}
+		}
+	}()
+
+	return valueChan
+}
+
+// Watch implements the Watcher interface. It forwards the events of the
+// wrapped collector, if it is a Watcher, and while the cache is stale checks
+// every retry interval whether the collector can be read again, reporting an
+// event with the collector's name as prefix once it can. A check reads the
+// collector; the documents of the successful one are served by the next
+// Collectors call instead of reading the collector again. The error of the
+// wrapped Watch is returned only if the cache is not stale.
+func (c *Cache) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	watcher, _ := c.collector.(Watcher)
+
+	var innerCh <-chan WatchEvent
+
+	if watcher != nil {
+		var err error
+
+		innerCh, err = watcher.Watch(ctx)
+		if err != nil && !c.Stale() {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		notified := false
+
+		for {
+			var event WatchEvent
+
+			select {
+			case <-ctx.Done():
+				return
+			case received, ok := <-innerCh:
+				if !ok {
+					innerCh = nil
+					continue
+				}
+
+				event = received
+			case <-ticker.C:
+				if !c.Stale() {
+					notified = false
+
+					// A rebuild may have recovered the cache before the probe.
+					if innerCh == nil && watcher != nil {
+						innerCh, _ = watcher.Watch(ctx)
+					}
+
+					continue
+				}
+
+				if notified || !c.recovered(ctx) {
+					continue
+				}
+
+				notified = true
+				event = WatchEvent{Prefix: c.Name(), Keys: nil, Revision: ""}
+
+				if innerCh == nil && watcher != nil {
+					innerCh, _ = watcher.Watch(ctx)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
+
+// recovered reports whether the wrapped collector of a stale cache can be
+// read again, keeping the documents read for the next Collectors call. It
+// returns false if the cache is no longer stale.
+func (c *Cache) recovered(ctx context.Context) bool {
+	multi, ok := c.collector.(config.MultiCollector)
+	if !ok {
+		return true
+	}
+
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	// A rebuild may have read the collector in the meantime.
+	if !c.Stale() {
+		return false
+	}
+
+	subs, err := multi.Collectors(ctx)
+	if err != nil {
+		return false
+	}
+
+	if subs == nil {
+		subs = []config.Collector{}
+	}
+
+	c.mu.Lock()
+	c.probed = subs
+	c.mu.Unlock()
+
+	return true
+}
+
+// read reads the documents of the wrapped collector, or takes the documents
+// of the last recovery probe, with the values as read and as stored in the
+// cache file.
+func (c *Cache) read(ctx context.Context) ([]cacheDocument, [][]config.Value, error) {
+	subs := []config.Collector{c.collector}
+
+	c.mu.Lock()
+	probed := c.probed
+	c.probed = nil
+	c.mu.Unlock()
+
+	if multi, ok := c.collector.(config.MultiCollector); ok {
+		subs = probed
+		if subs == nil {
+			var err error
+
+			subs, err = multi.Collectors(ctx)
+			if err != nil {
+				return nil, nil, err //nolint:wrapcheck
+			}
+		}
+	}
+
+	docs := make([]cacheDocument, 0, len(subs))
+	values := make([][]config.Value, 0, len(subs))
+
+	for _, sub := range subs {
+		if sub == nil {
+			continue
+		}
+
+		doc := cacheDocument{
+			Name:      sub.Name(),
+			Source:    cacheSourceType(sub.Source()),
+			Revision:  sub.Revision(),
+			KeepOrder: sub.KeepOrder(),
+			Values:    nil,
+		}
+
+		var docValues []config.Value
+
+		for val := range sub.Read(ctx) {
+			docValues = append(docValues, val)
+
+			var raw any
+			if val.Get(&raw) != nil {
+				continue
+			}
+
+			node, err := encodeCacheValue(raw)
+			if err != nil {
+				continue
+			}
+
+			doc.Values = append(doc.Values, cacheValue{
+				Path:   val.Meta().Key,
+				Source: val.Meta().Source.Name,
+				Value:  node,
+			})
+		}
+
+		docs = append(docs, doc)
+		values = append(values, docValues)
+	}
+
+	// A cancelled read may have been cut short.
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	return docs, values, nil
+}
+
+// save writes the documents to the cache file, replacing it atomically.
+func (c *Cache) save(docs []cacheDocument) error {
+	data, err := yaml.Marshal(cacheFile{Revision: c.collector.Revision(), Documents: docs})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrCache, c.path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCache, err)
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("%w: %w", ErrCache, err)
+	}
+
+	return nil
+}
+
+// load reads the cache file.
+func (c *Cache) load() (cacheFile, error) {
+	var cached cacheFile
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return cached, fmt.Errorf("%w: %w", ErrCache, err)
+	}
+
+	err = yaml.Unmarshal(data, &cached)
+	if err != nil {
+		return cached, fmt.Errorf("%w: %s: %w", ErrCache, c.path, err)
+	}
+
+	return cached, nil
+}
+
+// encodeCacheValue encodes a value for the cache file.
+func encodeCacheValue(value any) (yaml.Node, error) {
+	var node yaml.Node
+
+	err := node.Encode(value)
+	if err != nil {
+		return node, fmt.Errorf("%w: %w", ErrCache, err)
+	}
+
+	tagCacheNumbers(&node, value)
+
+	return node, nil
+}
+
+// tagCacheNumbers tags the numbers of value, encoded as node, with their
+// type: float64 as "!!float", since the encoder writes an integral float like
+// an integer, and the number types other than int and float64, which YAML
+// does not distinguish, with a local tag such as "!int64".
+func tagCacheNumbers(node *yaml.Node, value any) {
+	switch typed := value.(type) {
+	case float64:
+		node.Tag = "!!float"
+		node.Style |= yaml.TaggedStyle
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		node.Tag = "!" + reflect.TypeOf(typed).Kind().String()
+		node.Style |= yaml.TaggedStyle
+	case map[string]any:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			tagCacheNumbers(node.Content[i+1], typed[node.Content[i].Value])
+		}
+	case []any:
+		for i, item := range typed {
+			if i < len(node.Content) {
+				tagCacheNumbers(node.Content[i], item)
+			}
+		}
+	}
+}
+
+// decodeCacheValue decodes a value encoded by encodeCacheValue.
+func decodeCacheValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		mapping := make(map[string]any, len(node.Content)/2) //nolint:mnd
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := decodeCacheValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			mapping[node.Content[i].Value] = value
+		}
+
+		return mapping, nil
+	case yaml.SequenceNode:
+		items := make([]any, 0, len(node.Content))
+
+		for _, item := range node.Content {
+			value, err := decodeCacheValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, value)
+		}
+
+		return items, nil
+	case yaml.ScalarNode:
+		if value, ok, err := decodeCacheNumber(node); ok {
+			return value, err
+		}
+	}
+
+	var value any
+
+	err := node.Decode(&value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCache, err)
+	}
+
+	return value, nil
+}
+
+// decodeCacheNumber decodes a number tagged with a local tag by
+// tagCacheNumbers. It returns false if the node has no such tag.
+func decodeCacheNumber(node *yaml.Node) (any, bool, error) {
+	var (
+		value any
+		err   error
+	)
+
+	switch node.Tag {
+	case "!int8":
+		value, err = parseCacheInt[int8](node.Value, 8)
+	case "!int16":
+		value, err = parseCacheInt[int16](node.Value, 16)
+	case "!int32":
+		value, err = parseCacheInt[int32](node.Value, 32)
+	case "!int64":
+		value, err = parseCacheInt[int64](node.Value, 64)
+	case "!uint":
+		value, err = parseCacheUint[uint](node.Value, strconv.IntSize)
+	case "!uint8":
+		value, err = parseCacheUint[uint8](node.Value, 8)
+	case "!uint16":
+		value, err = parseCacheUint[uint16](node.Value, 16)
+	case "!uint32":
+		value, err = parseCacheUint[uint32](node.Value, 32)
+	case "!uint64":
+		value, err = parseCacheUint[uint64](node.Value, 64)
+	case "!float32":
+		var parsed float64
+
+		parsed, err = strconv.ParseFloat(node.Value, 32)
+		value = float32(parsed)
+	default:
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: line %d: %w", ErrCache, node.Line, err)
+	}
+
+	return value, true, nil
+}
+
+// parseCacheInt parses a signed integer of the given size.
+func parseCacheInt[T int8 | int16 | int32 | int64](text string, bits int) (T, error) {
+	value, err := strconv.ParseInt(text, 10, bits)
+
+	return T(value), err //nolint:wrapcheck
+}
+
+// parseCacheUint parses an unsigned integer of the given size.
+func parseCacheUint[T uint | uint8 | uint16 | uint32 | uint64](text string, bits int) (T, error) {
+	value, err := strconv.ParseUint(text, 10, bits)
+
+	return T(value), err //nolint:wrapcheck
+}
+
+// cachedValues builds the values of a document stored in the cache file.
+// Values that cannot be decoded are skipped.
+func cachedValues(doc cacheDocument) []config.Value {
+	values := make([]config.Value, 0, len(doc.Values))
+
+	for _, cached := range doc.Values {
+		raw, err := decodeCacheValue(&cached.Value)
+		if err != nil {
+			continue
+		}
+
+		node := tree.New()
+		node.Value = raw
+		node.Source = cached.Source
+
+		// An empty mapping is merged as an empty node; the annotation
+		// writes it back as "{}", as for a YAML source.
+		if mapping, ok := raw.(map[string]any); ok && len(mapping) == 0 {
+			yamlNode := cached.Value
+			node.SetAnnotation(config.YAMLAnnotation{Key: nil, Val: &yamlNode})
+		}
+
+		values = append(values, tree.NewValue(node, config.NewKeyPathFromSegments(cached.Path)))
+	}
+
+	return values
+}
+
+// cachedDocument is an unexported Collector emitting the values of a
+// document read through a Cache.
+type cachedDocument struct {
+	doc    cacheDocument
+	values []config.Value
+	stale  bool
+}
+
+// Read emits the document's values.
+func (d *cachedDocument) Read(ctx context.Context) <-chan config.Value {
+	valueChan := make(chan config.Value)
+
+	go func() {
+		defer close(valueChan)
+
+		for _, val := range d.values {
+			select {
+			case <-ctx.Done():
+				return
+			case valueChan <- val:
+			}
+		}
+	}()
+
+	return valueChan
+}
+
+// Name returns the document's source name.
+func (d *cachedDocument) Name() string { return d.doc.Name }
+
+// Source returns the document's source type.
+func (d *cachedDocument) Source() config.SourceType { return config.SourceType(d.doc.Source) }
+
+// Revision returns the document's revision.
+func (d *cachedDocument) Revision() config.RevisionType { return d.doc.Revision }
+
+// KeepOrder returns whether key order should be preserved.
+func (d *cachedDocument) KeepOrder() bool { return d.doc.KeepOrder }
+
+// Stale returns true if the document was served from the cache file.
+func (d *cachedDocument) Stale() bool { return d.stale }
@@ -0,0 +1,377 @@
+package collectors_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/internal/testutil"
+)
+
+var errBackendDown = errors.New("backend is down")
+
+// flakyStorage is a Storage collector whose backend can be made unreachable.
+type flakyStorage struct {
+	*collectors.Storage
+
+	down  atomic.Bool
+	reads atomic.Int32
+
+	// hold makes the next read close probing and wait for release before
+	// returning.
+	hold             atomic.Bool
+	probing, release chan struct{}
+}
+
+func (f *flakyStorage) Collectors(ctx context.Context) ([]config.Collector, error) {
+	if f.down.Load() {
+		return nil, errBackendDown
+	}
+
+	f.reads.Add(1)
+
+	subs, err := f.Storage.Collectors(ctx)
+
+	if f.hold.CompareAndSwap(true, false) {
+		close(f.probing)
+		<-f.release
+	}
+
+	return subs, err //nolint:wrapcheck
+}
+
+func newFlakyStorage(mock *testutil.MockStorage) *flakyStorage {
+	storage := collectors.NewStorage(testutil.NewRawTyped(mock, "/config/"), "/config/",
+		collectors.NewYamlFormat()).WithName("etcd")
+
+	return &flakyStorage{
+		Storage: storage,
+		down:    atomic.Bool{},
+		reads:   atomic.Int32{},
+		hold:    atomic.Bool{},
+		probing: nil,
+		release: nil,
+	}
+}
+
+func buildCache(t *testing.T, cache *collectors.Cache) (config.Config, []error) {
+	t.Helper()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(cache)
+
+	return builder.Build(t.Context())
+}
+
+func TestCache_ServesStaleCopy(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config-cache.yaml")
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("server:\n  port: 8080\n  host: '0.0.0.0'\n"))
+
+	storage := newFlakyStorage(mock)
+
+	cfg, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Empty(t, errs)
+
+	port, ok := cfg.Lookup(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	assert.False(t, port.Meta().Stale)
+
+	revision := port.Meta().Revision
+
+	// A restart while the backend is down.
+	storage.down.Store(true)
+
+	cache := collectors.NewCache(storage, path)
+
+	cfg, errs = buildCache(t, cache)
+	require.Empty(t, errs)
+	assert.True(t, cache.Stale())
+	require.ErrorIs(t, cache.Err(), errBackendDown)
+
+	port, ok = cfg.Lookup(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	assert.True(t, port.Meta().Stale)
+	assert.Equal(t, "etcd:/config/app", port.Meta().Source.Name)
+	assert.Equal(t, revision, port.Meta().Revision)
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "0.0.0.0", config.MustGet[string](&cfg, config.NewKeyPath("server/host")))
+
+	// The backend is back with a new document.
+	storage.down.Store(false)
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("server:\n  port: 9090\n"))
+
+	cfg, errs = buildCache(t, cache)
+	require.Empty(t, errs)
+	assert.False(t, cache.Stale())
+	require.NoError(t, cache.Err())
+
+	port, ok = cfg.Lookup(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	assert.False(t, port.Meta().Stale)
+	assert.Equal(t, 9090, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+}
+
+func TestCache_Watch_ProbeRacesRebuild(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 1\n"))
+
+	storage := newFlakyStorage(mock)
+
+	_, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Empty(t, errs)
+
+	storage.down.Store(true)
+
+	cache := collectors.NewCache(storage, path).WithRetryInterval(testPollInterval)
+
+	_, errs = buildCache(t, cache)
+	require.Empty(t, errs)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := cache.Watch(ctx)
+	require.NoError(t, err)
+
+	// The recovery check reads "a: 1" and is held before it returns.
+	storage.probing, storage.release = make(chan struct{}), make(chan struct{})
+	storage.hold.Store(true)
+	storage.down.Store(false)
+
+	<-storage.probing
+
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 2\n"))
+
+	rebuilt := make(chan int)
+
+	go func() {
+		cfg, errs := buildCache(t, cache)
+		assert.Empty(t, errs)
+
+		rebuilt <- config.MustGet[int](&cfg, config.NewKeyPath("a"))
+	}()
+
+	time.Sleep(testPollInterval)
+	close(storage.release)
+
+	first := <-rebuilt
+
+	requireWatchEvent(t, events)
+
+	cfg, errs := buildCache(t, cache)
+	require.Empty(t, errs)
+
+	// The configuration never goes back to an older read.
+	assert.GreaterOrEqual(t, config.MustGet[int](&cfg, config.NewKeyPath("a")), first)
+	assert.Equal(t, 2, config.MustGet[int](&cfg, config.NewKeyPath("a")))
+}
+
+func TestCache_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte(`ratio: 1.0
+scale: 2.5
+count: 3
+version: '1.0'
+enabled: true
+obj: {}
+list: []
+nested:
+  items: [1.0, {}]
+`))
+
+	storage := newFlakyStorage(mock)
+
+	cfg, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Empty(t, errs)
+
+	storage.down.Store(true)
+
+	cache := collectors.NewCache(storage, path)
+
+	cached, errs := buildCache(t, cache)
+	require.Empty(t, errs)
+	require.True(t, cache.Stale())
+
+	for _, key := range []string{"ratio", "scale", "count", "version", "enabled", "obj", "list", "nested/items/0"} {
+		path := config.NewKeyPath(key)
+
+		var expected, actual any
+
+		_, err := cfg.Get(path, &expected)
+		require.NoError(t, err, key)
+
+		_, err = cached.Get(path, &actual)
+		require.NoError(t, err, key)
+
+		assert.Equal(t, expected, actual, key)
+		assert.IsType(t, expected, actual, key)
+	}
+
+	subs, err := cache.Collectors(t.Context())
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, config.StorageSource, subs[0].Source())
+
+	assert.Equal(t, 1.0, config.MustGet[float64](&cached, config.NewKeyPath("ratio")))
+	assert.Empty(t, cfg.Diff(&cached))
+
+	out, err := cached.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "obj: {}\n")
+	assert.Contains(t, string(out), "- {}\n")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "source: storage\n")
+}
+
+func TestCache_RoundTrip_Numbers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+
+	values := map[string]any{
+		"int":     7,
+		"int8":    int8(-8),
+		"int32":   int32(32),
+		"uint":    uint(1),
+		"uint64":  uint64(1 << 63),
+		"float32": float32(1.5),
+		"float64": 2.0,
+		"list":    []any{int64(1), 1.0, "1"},
+	}
+
+	_, errs := buildCache(t, collectors.NewCache(collectors.NewMap(values), path))
+	require.Empty(t, errs)
+
+	// Serve the file written for the map.
+	storage := newFlakyStorage(testutil.NewMockStorage())
+	storage.down.Store(true)
+
+	cached, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Empty(t, errs)
+
+	for key, expected := range values {
+		var actual any
+
+		_, err := cached.Get(config.NewKeyPath(key), &actual)
+		require.NoError(t, err, key)
+		assert.Equal(t, expected, actual, key)
+	}
+}
+
+func TestCache_NoCacheFile(t *testing.T) {
+	t.Parallel()
+
+	storage := newFlakyStorage(testutil.NewMockStorage())
+	storage.down.Store(true)
+
+	_, errs := buildCache(t, collectors.NewCache(storage, filepath.Join(t.TempDir(), "cache.yaml")))
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errBackendDown)
+}
+
+func TestCache_CorruptCacheFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("documents: {"), 0o600))
+
+	storage := newFlakyStorage(testutil.NewMockStorage())
+	storage.down.Store(true)
+
+	_, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errBackendDown)
+	require.ErrorIs(t, errs[0], collectors.ErrCache)
+}
+
+func TestCache_PlainCollector(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+	cache := collectors.NewCache(collectors.NewMap(map[string]any{
+		"roles": []any{"router", "storage"},
+		"debug": true,
+	}), path)
+
+	cfg, errs := buildCache(t, cache)
+	require.Empty(t, errs)
+	assert.Equal(t, []string{"router", "storage"}, config.MustGet[[]string](&cfg, config.NewKeyPath("roles")))
+	assert.True(t, config.MustGet[bool](&cfg, config.NewKeyPath("debug")))
+	assert.FileExists(t, path)
+}
+
+func TestCache_Watch_Recovery(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cache.yaml")
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 1\n"))
+
+	storage := newFlakyStorage(mock)
+
+	_, errs := buildCache(t, collectors.NewCache(storage, path))
+	require.Empty(t, errs)
+
+	storage.down.Store(true)
+
+	cache := collectors.NewCache(storage, path).WithRetryInterval(testPollInterval)
+
+	_, errs = buildCache(t, cache)
+	require.Empty(t, errs)
+	require.True(t, cache.Stale())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := cache.Watch(ctx)
+	require.NoError(t, err)
+
+	requireNoWatchEvent(t, events)
+
+	storage.down.Store(false)
+
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, "etcd", event.Prefix)
+
+	// Until the configuration is rebuilt, the recovery is reported once.
+	requireNoWatchEvent(t, events)
+
+	reads := storage.reads.Load()
+
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 2\n"))
+
+	// The rebuild serves the documents read by the recovery check.
+	cfg, errs := buildCache(t, cache)
+	require.Empty(t, errs)
+	assert.False(t, cache.Stale())
+	assert.Equal(t, reads, storage.reads.Load())
+	assert.Equal(t, 1, config.MustGet[int](&cfg, config.NewKeyPath("a")))
+
+	cfg, errs = buildCache(t, cache)
+	require.Empty(t, errs)
+	assert.Equal(t, 2, config.MustGet[int](&cfg, config.NewKeyPath("a")))
+}
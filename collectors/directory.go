@@ -3,9 +3,13 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/tree"
@@ -18,24 +22,44 @@ import (
 // are used for source identification; the file content determines the tree
 // structure.
 //
+// With [Directory.WithFormats] the format of each file is detected by a
+// [FormatRegistry] instead, so one collector can load a directory holding
+// e.g. a.yaml, b.yml and c.json.
+//
 // When recursive mode is enabled, subdirectories are scanned recursively.
 // Symbolic links to files are followed, but symbolic links to directories
 // are skipped to prevent infinite loops and cyclic traversals.
+//
+// [NewDirectoryFS] reads the directory from an fs.FS (such as embed.FS)
+// instead of the local filesystem, with the same semantics.
+//
+// Directory implements [Watcher] by rescanning the directory, so a
+// [config.Reloader] rebuilds the configuration when a file is added, removed
+// or modified, including files replaced by an atomic rename.
 type Directory struct {
 	name       string
 	sourceType config.SourceType
 	revision   config.RevisionType
 	keepOrder  bool
+	fsys       fs.FS
 	path       string
 	extension  string
 	format     Format
+	formats    *FormatRegistry
+	multiDoc   bool
 	recursive  bool
+	interval   time.Duration
+	debounce   time.Duration
+
+	mu      sync.RWMutex
+	scanned config.RevisionType
 }
 
 // NewDirectory creates a new Directory collector that reads all files with
 // the given extension from the specified directory path. Each file's content
 // is parsed using the provided Format.
-// The extension should include the leading dot (e.g., ".yaml").
+// The extension should include the leading dot (e.g., ".yaml"). The format
+// may be nil when formats are detected with [Directory.WithFormats].
 func NewDirectory(
 	path string,
 	extension string,
@@ -46,13 +70,35 @@ func NewDirectory(
 		sourceType: config.FileSource,
 		revision:   "",
 		keepOrder:  false,
+		fsys:       nil,
 		path:       path,
 		extension:  extension,
 		format:     format,
+		formats:    nil,
+		multiDoc:   false,
 		recursive:  false,
+		interval:   DefaultFilePollInterval,
+		debounce:   DefaultFileDebounce,
+		mu:         sync.RWMutex{},
+		scanned:    "",
 	}
 }
 
+// NewDirectoryFS creates a new Directory collector like [NewDirectory], but
+// reads the directory at path from fsys. The path follows the fs.FS naming
+// rules: it is slash-separated and unrooted, with "." naming the root of fsys.
+//
+//	//go:embed defaults
+//	var defaults embed.FS
+//
+//	collectors.NewDirectoryFS(defaults, "defaults", ".yaml", collectors.NewYamlFormat())
+func NewDirectoryFS(fsys fs.FS, path string, extension string, format Format) *Directory {
+	directory := NewDirectory(path, extension, format)
+	directory.fsys = fsys
+
+	return directory
+}
+
 // WithName sets a custom name prefix for the collector (default "directory").
 // The final SourceInfo.Name for each value will be "<name>:<path>/<filename>",
 // where <filename> is the name of the file from which the value was read.
@@ -83,12 +129,56 @@ func (d *Directory) WithKeepOrder(keep bool) *Directory {
 	return d
 }
 
+// WithFormats enables format detection with the given registry. Files are
+// parsed with the format registered for their extension; a file with an
+// unregistered extension is parsed with the collector's Format, or, when it is
+// nil, with the format sniffed from its content. If the collector's extension
+// is empty, only files with an extension known to the registry are read:
+//
+//	collectors.NewDirectory("/etc/app", "", nil).
+//		WithFormats(collectors.DefaultFormatRegistry())
+func (d *Directory) WithFormats(registry *FormatRegistry) *Directory {
+	d.formats = registry
+	return d
+}
+
+// WithMultiDocument sets whether every document of a multi-document file
+// (e.g., a YAML stream separated by "---") is loaded as a separate
+// sub-collector, in stream order (default false: only the first document is
+// read). It applies to formats implementing [MultiDocumentFormat]. Documents
+// of a file holding several of them are named "<name>:<path>/<file>#<n>",
+// with n starting at 1.
+func (d *Directory) WithMultiDocument(multiDoc bool) *Directory {
+	d.multiDoc = multiDoc
+	return d
+}
+
 // WithRecursive sets whether to scan subdirectories recursively (default false).
 func (d *Directory) WithRecursive(recursive bool) *Directory {
 	d.recursive = recursive
 	return d
 }
 
+// WithPollInterval sets the interval at which Watch rescans the directory
+// (default [DefaultFilePollInterval]). A value <= 0 restores the default.
+func (d *Directory) WithPollInterval(interval time.Duration) *Directory {
+	if interval <= 0 {
+		interval = DefaultFilePollInterval
+	}
+
+	d.interval = interval
+
+	return d
+}
+
+// WithDebounce sets how long the content of the directory must stay the same
+// before Watch reports a change (default [DefaultFileDebounce]). A value <= 0
+// reports changes as soon as they are seen.
+func (d *Directory) WithDebounce(debounce time.Duration) *Directory {
+	d.debounce = max(debounce, 0)
+	return d
+}
+
 // Name returns the collector's name.
 func (d *Directory) Name() string {
 	return d.name
@@ -99,9 +189,19 @@ func (d *Directory) Source() config.SourceType {
 	return d.sourceType
 }
 
-// Revision returns the collector's current revision.
+// Revision returns the collector's current revision: the one set with
+// WithRevision or, by default, a hash over the paths and contents of the
+// files read by the last Collectors call. Each file is reported with the
+// SHA-256 hash of its content as its own revision.
 func (d *Directory) Revision() config.RevisionType {
-	return d.revision
+	if d.revision != "" {
+		return d.revision
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.scanned
 }
 
 // KeepOrder returns whether the collector preserves key order.
@@ -123,14 +223,38 @@ func (d *Directory) Recursive() bool {
 // Symbolic links to files are followed, but symbolic links to directories
 // are skipped.
 func (d *Directory) Collectors(_ context.Context) ([]config.Collector, error) {
-	entries, err := os.ReadDir(d.path)
+	files, err := d.scan(d.path)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrDirectoryRead, err)
-	} else if len(entries) == 0 {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.scanned = d.filesRevision(files)
+	d.mu.Unlock()
+
+	if len(files) == 0 {
 		return nil, nil
 	}
 
-	return d.collectFiles(d.path)
+	return d.collectFiles(files)
+}
+
+// Watch implements the Watcher interface. It rescans the directory at the
+// poll interval and sends an event when a matching file is added, removed or
+// modified. Failed scans are skipped; the directory is scanned again at the
+// next tick.
+func (d *Directory) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	d.mu.RLock()
+	baseline := d.scanned
+	d.mu.RUnlock()
+
+	if baseline == "" {
+		baseline, _ = d.digest()
+	}
+
+	event := WatchEvent{Prefix: d.path, Keys: nil, Revision: ""}
+
+	return pollChanges(ctx, d.interval, d.debounce, baseline, d.digest, event), nil
 }
 
 // Read performs a directory scan and emits all values from all files on a
@@ -163,13 +287,21 @@ func (d *Directory) Read(ctx context.Context) <-chan config.Value {
 	return valueChan
 }
 
-func (d *Directory) collectFiles(dirPath string) ([]config.Collector, error) {
-	entries, err := os.ReadDir(dirPath)
+// directoryFile is a file of a Directory that matches its filters.
+type directoryFile struct {
+	path string
+	data []byte
+}
+
+// scan reads the files matching the collector's filters from dirPath and,
+// in recursive mode, from its subdirectories.
+func (d *Directory) scan(dirPath string) ([]directoryFile, error) {
+	entries, err := d.readDir(dirPath)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrDirectoryRead, err)
 	}
 
-	docs := make([]config.Collector, 0, len(entries))
+	files := make([]directoryFile, 0, len(entries))
 
 	for _, entry := range entries {
 		info, err := entry.Info()
@@ -178,9 +310,9 @@ func (d *Directory) collectFiles(dirPath string) ([]config.Collector, error) {
 		}
 
 		if info.Mode()&os.ModeSymlink != 0 {
-			linkPath := filepath.Join(dirPath, entry.Name())
+			linkPath := d.join(dirPath, entry.Name())
 
-			targetInfo, err := os.Stat(linkPath)
+			targetInfo, err := d.stat(linkPath)
 			if err != nil {
 				return nil, fmt.Errorf("%w: %w", ErrFile, err)
 			} else if targetInfo.IsDir() {
@@ -190,65 +322,192 @@ func (d *Directory) collectFiles(dirPath string) ([]config.Collector, error) {
 
 		if entry.IsDir() {
 			if d.recursive {
-				subdirPath := filepath.Join(dirPath, entry.Name())
+				subdirPath := d.join(dirPath, entry.Name())
 
-				subdocs, err := d.collectFiles(subdirPath)
+				subfiles, err := d.scan(subdirPath)
 				if err != nil {
 					return nil, err
 				}
 
-				docs = append(docs, subdocs...)
+				files = append(files, subfiles...)
 			}
 
 			continue
 		}
 
-		if !strings.HasSuffix(entry.Name(), d.extension) {
+		if !d.matches(entry.Name()) {
 			continue
 		}
 
-		filePath := filepath.Join(dirPath, entry.Name())
+		filePath := d.join(dirPath, entry.Name())
 
-		data, err := os.ReadFile(filepath.Clean(filePath))
+		data, err := d.readFile(filePath)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %w", ErrFile, err)
-		} else if len(data) == 0 {
+		}
+
+		files = append(files, directoryFile{path: filePath, data: data})
+	}
+
+	return files, nil
+}
+
+func (d *Directory) collectFiles(files []directoryFile) ([]config.Collector, error) {
+	docs := make([]config.Collector, 0, len(files))
+
+	for _, file := range files {
+		if len(file.data) == 0 {
 			continue
 		}
 
-		subtree, err := d.parseData(filePath, data)
+		subtrees, err := d.parseData(file.path, file.data)
 		if err != nil {
 			return nil, err
 		}
 
-		relPath, _ := filepath.Rel(d.path, filePath)
-		docName := d.sourceName(relPath)
-		setSource(subtree, docName)
-
-		docs = append(docs, &directoryDocument{
-			docName:   docName,
-			srcType:   d.sourceType,
-			revision:  d.revision,
-			keepOrder: d.keepOrder,
-			root:      subtree,
-		})
+		relPath := d.rel(file.path)
+
+		revision := d.revision
+		if revision == "" {
+			revision = contentRevision(file.data)
+		}
+
+		for i, subtree := range subtrees {
+			docName := documentName(d.sourceName(relPath), i, len(subtrees))
+			setSource(subtree, docName)
+
+			docs = append(docs, &directoryDocument{
+				docName:   docName,
+				srcType:   d.sourceType,
+				revision:  revision,
+				keepOrder: d.keepOrder,
+				root:      subtree,
+			})
+		}
 	}
 
 	return docs, nil
 }
 
-// parseData parses raw bytes using the collector's format. filePath is
-// embedded into any FormatParseError so the caller can locate the file.
-func (d *Directory) parseData(filePath string, data []byte) (*tree.Node, error) {
-	reader := strings.NewReader(string(data))
-	format := d.format.From(reader)
+// digest scans the directory and returns the revision of its content.
+func (d *Directory) digest() (config.RevisionType, error) {
+	files, err := d.scan(d.path)
+	if err != nil {
+		return "", err
+	}
+
+	return d.filesRevision(files), nil
+}
+
+// filesRevision returns the revision of the scanned files: a hash over their
+// paths and contents, which changes when a file is added, removed or
+// modified.
+func (d *Directory) filesRevision(files []directoryFile) config.RevisionType {
+	var digest strings.Builder
+
+	for _, file := range files {
+		digest.WriteString(d.rel(file.path))
+		digest.WriteByte(0)
+		digest.WriteString(string(contentRevision(file.data)))
+		digest.WriteByte('\n')
+	}
+
+	return contentRevision([]byte(digest.String()))
+}
+
+// readDir reads the directory at dirPath.
+func (d *Directory) readDir(dirPath string) ([]fs.DirEntry, error) {
+	if d.fsys != nil {
+		return fs.ReadDir(d.fsys, dirPath) //nolint:wrapcheck
+	}
+
+	return os.ReadDir(dirPath) //nolint:wrapcheck
+}
+
+// readFile reads the file at filePath.
+func (d *Directory) readFile(filePath string) ([]byte, error) {
+	if d.fsys != nil {
+		return fs.ReadFile(d.fsys, filePath) //nolint:wrapcheck
+	}
+
+	return os.ReadFile(filepath.Clean(filePath)) //nolint:wrapcheck
+}
+
+// stat returns the file info of filePath, following symbolic links.
+func (d *Directory) stat(filePath string) (fs.FileInfo, error) {
+	if d.fsys != nil {
+		return fs.Stat(d.fsys, filePath) //nolint:wrapcheck
+	}
+
+	return os.Stat(filePath) //nolint:wrapcheck
+}
+
+// join joins a directory path and an entry name.
+func (d *Directory) join(dirPath, name string) string {
+	if d.fsys != nil {
+		return path.Join(dirPath, name)
+	}
+
+	return filepath.Join(dirPath, name)
+}
+
+// rel returns filePath relative to the collector's directory.
+func (d *Directory) rel(filePath string) string {
+	if d.fsys != nil {
+		if d.path == "." {
+			return filePath
+		}
+
+		return strings.TrimPrefix(filePath, d.path+"/")
+	}
+
+	relPath, _ := filepath.Rel(d.path, filePath)
+
+	return relPath
+}
+
+// matches reports whether a file with the given name should be read.
+func (d *Directory) matches(name string) bool {
+	if d.extension == "" && d.formats != nil {
+		_, ok := d.formats.ByExtension(name)
+		return ok
+	}
+
+	return strings.HasSuffix(name, d.extension)
+}
+
+// formatFor returns the format to parse the file at filePath with.
+func (d *Directory) formatFor(filePath string, data []byte) (Format, error) {
+	if d.formats == nil {
+		return d.format, nil
+	}
+
+	if format, ok := d.formats.ByExtension(filePath); ok {
+		return format, nil
+	} else if d.format != nil {
+		return d.format, nil
+	}
+
+	return d.formats.Detect("", "", data)
+}
+
+// parseData parses raw bytes using the collector's format, into one tree per
+// document. filePath is embedded into any FormatParseError so the caller can
+// locate the file.
+func (d *Directory) parseData(filePath string, data []byte) ([]*tree.Node, error) {
+	format, err := d.formatFor(filePath, data)
+	if err != nil {
+		return nil, NewFormatParseError(filePath, err)
+	}
+
+	reader := namedReader{Reader: strings.NewReader(string(data)), name: filePath}
 
-	node, err := format.Parse()
+	nodes, err := parseDocuments(format.From(reader), d.multiDoc)
 	if err != nil {
 		return nil, NewFormatParseError(filePath, err)
 	}
 
-	return node, nil
+	return nodes, nil
 }
 
 // sourceName builds the source identifier for a specific file.
@@ -3,7 +3,9 @@ package collectors_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -508,3 +510,72 @@ func writeTestFile(t *testing.T, dir, name, content string) {
 	err := os.WriteFile(dir+"/"+name, []byte(content), 0o600)
 	require.NoError(t, err)
 }
+
+func TestNewDirectoryFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"defaults/a.yaml":         &fstest.MapFile{Data: []byte("server:\n  port: 3301\n")},
+		"defaults/b.yaml":         &fstest.MapFile{Data: []byte("server:\n  port: 3302\n")},
+		"defaults/notes.txt":      &fstest.MapFile{Data: []byte("ignored")},
+		"defaults/empty.yaml":     &fstest.MapFile{Data: nil},
+		"defaults/nested/c.yaml":  &fstest.MapFile{Data: []byte("log: debug\n")},
+		"defaults/nested/d/e.yml": &fstest.MapFile{Data: []byte("deep: true\n")},
+	}
+
+	collector := collectors.NewDirectoryFS(fsys, "defaults", ".yaml", collectors.NewYamlFormat()).
+		WithName("defaults")
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assert.Equal(t, "defaults:defaults/a.yaml", subs[0].Name())
+	assert.Equal(t, "defaults:defaults/b.yaml", subs[1].Name())
+
+	subs, err = collector.WithRecursive(true).Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+	assert.Equal(t, "defaults:defaults/nested/c.yaml", subs[2].Name())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, 3302, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "debug", config.MustGet[string](&cfg, config.NewKeyPath("log")))
+
+	root := collectors.NewDirectoryFS(fsys, ".", "", nil).
+		WithFormats(collectors.DefaultFormatRegistry()).WithRecursive(true)
+
+	subs, err = root.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 4)
+	assert.Equal(t, "directory:./defaults/nested/d/e.yml", subs[3].Name())
+
+	_, err = collectors.NewDirectoryFS(fsys, "missing", ".yaml", collectors.NewYamlFormat()).
+		Collectors(context.Background())
+	require.ErrorIs(t, err, collectors.ErrDirectoryRead)
+}
+
+func TestNewDirectoryFS_Symlinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "app.yaml", "port: 8080")
+
+	otherDir := t.TempDir()
+	writeTestFile(t, otherDir, "db.yaml", "dbhost: postgres")
+
+	require.NoError(t, os.Symlink(filepath.Join(dir, "app.yaml"), filepath.Join(dir, "linked.yaml")))
+	require.NoError(t, os.Symlink(otherDir, filepath.Join(dir, "other")))
+
+	collector := collectors.NewDirectoryFS(os.DirFS(dir), ".", ".yaml", collectors.NewYamlFormat()).
+		WithRecursive(true)
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assert.Equal(t, "directory:./app.yaml", subs[0].Name())
+	assert.Equal(t, "directory:./linked.yaml", subs[1].Name())
+}
@@ -8,19 +8,48 @@
 //     `config`/`yaml` tags; see also [StructToMap].
 //   - [Env] — reads configuration from environment variables, with
 //     configurable prefix, delimiter, and key transformation.
+//   - [Flags] — reads explicitly set command-line flags of a flag.FlagSet (or
+//     a pflag-like set via [NewPFlags]); [Flags.Defaults] adds flag defaults
+//     as a separate layer.
 //   - [Storage] — reads multiple configuration documents from a key-value
-//     storage (e.g., etcd) under a common prefix, with integrity verification.
+//     storage (e.g., etcd) under a common prefix, with integrity verification;
+//     [Storage.WithFlatKeys] maps every key to a single value instead, and
+//     [Storage.WithIntegrityMode] fails on or skips unverified documents.
+//   - [StoragePublisher] — writes configuration documents into a key-value
+//     storage in the layout read by [Storage], with hashes and signatures, in
+//     a single transaction with an optional revision check.
+//   - [Cache] — wraps a collector with a last-known-good copy persisted in a
+//     local file, served with MetaInfo.Stale set when the collector fails.
 //   - [Directory] — reads multiple configuration files from a filesystem
-//     directory, with optional recursive scanning.
+//     directory, with optional recursive scanning; [NewDirectoryFS] reads
+//     it from an fs.FS such as embed.FS.
 //   - [Source] / [DataSource] — abstraction over a single data stream
-//     (file, storage key, etc.) used by the generic collector.
+//     (file, storage key, etc.) used by the generic collector; [NewFileFS]
+//     reads a file from an fs.FS.
+//   - [HTTP] — a [DataSource] fetching a document over HTTP(S) with
+//     conditional requests, retries and polling for changes.
+//   - [Documents] — reads every document of a multi-document stream (e.g.,
+//     "---"-separated YAML) as a separate sub-collector.
 //
 // # Format and Watching
 //
 //   - [Format] — interface for parsing raw data (e.g., YAML) into a tree.Node.
-//   - [YamlFormat] — YAML implementation of [Format].
+//   - [YamlFormat] — YAML implementation of [Format] with merge keys, custom
+//     tag handlers ([WithYamlTag]), file includes ([WithYamlIncludes]) and
+//     multi-document streams ([MultiDocumentFormat]).
+//   - [JSONFormat] — JSON implementation of [Format] with key order and
+//     line/column positions.
+//   - [TOMLFormat] — TOML implementation of [Format] (tables, arrays of
+//     tables, date-times).
+//   - [DotenvFormat], [PropertiesFormat] — flat key/value formats (.env and
+//     .properties files) mapping keys onto key paths like [Env].
+//   - [FormatRegistry] — formats by file extension and MIME type, with
+//     content sniffing; used by [AutoFormat] and [Directory.WithFormats].
 //   - [Watcher] — interface for reactive change notifications from storage
-//     backends.
+//     backends; [File] and [Directory] implement it by polling content
+//     hashes, and [Storage.Watch] reports the changed keys of every event.
+//   - [CoalesceWatchEvents] — merges bursts of watch events within a window;
+//     see also [Storage.WithWatchWindow].
 //
 // # Builder Pattern
 //
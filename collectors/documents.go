@@ -0,0 +1,134 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// Documents implements config.Collector and config.MultiCollector for a data
+// source holding several documents, such as a YAML stream separated by "---".
+// Each document is merged independently as a separate sub-collector, in
+// stream order, so later documents override earlier ones.
+//
+// Formats that do not implement [MultiDocumentFormat] yield a single
+// document.
+type Documents struct {
+	source DataSource
+	format Format
+	docs   []*tree.Node
+}
+
+// NewDocuments fetches the source and parses all of its documents with the
+// format. Empty documents are skipped.
+func NewDocuments(ctx context.Context, source DataSource, format Format) (*Documents, error) {
+	reader, err := source.FetchStream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFetchStream, err)
+	}
+	defer reader.Close() //nolint:errcheck
+
+	docs, err := parseDocuments(format.From(reader), true)
+	if err != nil {
+		return nil, NewFormatParseError(source.Name(), err)
+	}
+
+	for i, doc := range docs {
+		setSource(doc, documentName(source.Name(), i, len(docs)))
+	}
+
+	return &Documents{
+		source: source,
+		format: format,
+		docs:   docs,
+	}, nil
+}
+
+// Name implements Collector interface.
+func (d *Documents) Name() string {
+	return d.source.Name()
+}
+
+// Source implements Collector interface.
+func (d *Documents) Source() config.SourceType {
+	return d.source.SourceType()
+}
+
+// Revision implements Collector interface.
+func (d *Documents) Revision() config.RevisionType {
+	return d.source.Revision()
+}
+
+// KeepOrder implements Collector interface.
+func (d *Documents) KeepOrder() bool {
+	return d.format.KeepOrder()
+}
+
+// Len returns the number of documents.
+func (d *Documents) Len() int {
+	return len(d.docs)
+}
+
+// Collectors implements config.MultiCollector. It returns one sub-collector
+// per document, named "<name>#<n>" with n starting at 1 when there are
+// several documents, and "<name>" otherwise.
+func (d *Documents) Collectors(_ context.Context) ([]config.Collector, error) {
+	subs := make([]config.Collector, 0, len(d.docs))
+
+	for i, doc := range d.docs {
+		subs = append(subs, &directoryDocument{
+			docName:   documentName(d.source.Name(), i, len(d.docs)),
+			srcType:   d.source.SourceType(),
+			revision:  d.source.Revision(),
+			keepOrder: d.format.KeepOrder(),
+			root:      doc,
+		})
+	}
+
+	return subs, nil
+}
+
+// Read emits the values of all documents on a single channel, in stream
+// order. The Builder uses Collectors for independent per-document merging.
+func (d *Documents) Read(ctx context.Context) <-chan config.Value {
+	valueChan := make(chan config.Value)
+
+	go func() {
+		defer close(valueChan)
+
+		for _, doc := range d.docs {
+			walkTree(ctx, doc, config.NewKeyPath(""), valueChan)
+		}
+	}()
+
+	return valueChan
+}
+
+// parseDocuments parses the data of format into one tree per document, or
+// into a single tree when multiDoc is false or the format does not support
+// several documents.
+func parseDocuments(format Format, multiDoc bool) ([]*tree.Node, error) {
+	if multi, ok := format.(MultiDocumentFormat); ok && multiDoc {
+		return multi.ParseDocuments() //nolint:wrapcheck
+	}
+
+	node, err := format.Parse()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return []*tree.Node{node}, nil
+}
+
+// documentName returns the source name of the document with the given index
+// among count documents.
+func documentName(name string, index, count int) string {
+	if count <= 1 {
+		return name
+	}
+
+	return name + "#" + strconv.Itoa(index+1)
+}
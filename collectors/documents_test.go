@@ -0,0 +1,132 @@
+package collectors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+const configStream = `server:
+  host: localhost
+  port: 3301
+---
+server:
+  port: 3302
+---
+debug: true
+`
+
+func TestNewDocuments(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "stream.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(configStream), 0o600))
+
+	docs, err := collectors.NewDocuments(context.Background(), collectors.NewFile(path),
+		collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	assert.Equal(t, "file", docs.Name())
+	assert.Equal(t, config.FileSource, docs.Source())
+	assert.True(t, docs.KeepOrder())
+	assert.Equal(t, 3, docs.Len())
+
+	subs, err := docs.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+	assert.Equal(t, "file#1", subs[0].Name())
+	assert.Equal(t, "file#3", subs[2].Name())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(docs)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, "localhost", config.MustGet[string](&cfg, config.NewKeyPath("server/host")))
+	assert.Equal(t, 3302, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.True(t, config.MustGet[bool](&cfg, config.NewKeyPath("debug")))
+
+	value, ok := cfg.Lookup(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	assert.Equal(t, "file#2", value.Meta().Source.Name)
+
+	values := make([]config.Value, 0, 4)
+	for value := range docs.Read(context.Background()) {
+		values = append(values, value)
+	}
+
+	assert.Len(t, values, 4)
+}
+
+func TestNewDocuments_SingleDocumentFormat(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"a": 1}`), 0o600))
+
+	docs, err := collectors.NewDocuments(context.Background(), collectors.NewFile(path),
+		collectors.NewJSONFormat())
+	require.NoError(t, err)
+	require.Equal(t, 1, docs.Len())
+
+	subs, err := docs.Collectors(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "file", subs[0].Name())
+}
+
+func TestNewDocuments_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := collectors.NewDocuments(context.Background(), collectors.NewFile("/nonexistent.yaml"),
+		collectors.NewYamlFormat())
+	require.ErrorIs(t, err, collectors.ErrFetchStream)
+
+	path := filepath.Join(t.TempDir(), "broken.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1\n---\nb: [\n"), 0o600))
+
+	_, err = collectors.NewDocuments(context.Background(), collectors.NewFile(path),
+		collectors.NewYamlFormat())
+	require.ErrorIs(t, err, collectors.ErrUnmarshall)
+
+	var parseErr *collectors.FormatParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "file", parseErr.Key)
+}
+
+func TestDirectory_WithMultiDocument(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml", configStream)
+	writeTestFile(t, dir, "b.yaml", "extra: 1\n")
+
+	collector := collectors.NewDirectory(dir, ".yaml", collectors.NewYamlFormat()).
+		WithName("config")
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+
+	subs, err = collector.WithMultiDocument(true).Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 4)
+	assert.Equal(t, "config:"+dir+"/a.yaml#2", subs[1].Name())
+	assert.Equal(t, "config:"+dir+"/b.yaml", subs[3].Name())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 3302, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.True(t, config.MustGet[bool](&cfg, config.NewKeyPath("debug")))
+}
@@ -0,0 +1,470 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// DotenvFormat implements Format interface for .env files.
+//
+// Every line holds a "KEY=value" assignment, optionally prefixed with
+// "export". Values may be unquoted (an inline comment after a space is
+// dropped), single-quoted (taken literally) or double-quoted (with \n, \t,
+// \", \\ and \$ escapes); quoted values may span several lines. References to
+// other variables, $VAR, ${VAR}, ${VAR:-default} and ${VAR-default}, are
+// expanded in unquoted and double-quoted values: variables assigned earlier
+// in the file take precedence over the environment.
+//
+// Keys are mapped onto key paths like [Env] does: lowercased and split by
+// the delimiter ("_" by default), so DB_HOST=localhost becomes db/host. All
+// values are strings.
+type DotenvFormat struct {
+	name      string
+	keepOrder bool
+	data      []byte
+	reader    io.Reader
+	keys      flatKeys
+	lookup    func(name string) (string, bool)
+}
+
+// DotenvOption configures a DotenvFormat.
+type DotenvOption func(*DotenvFormat)
+
+// WithDotenvPrefix sets a prefix to strip from keys. If set, only keys
+// starting with this prefix are processed.
+func WithDotenvPrefix(prefix string) DotenvOption {
+	return func(d *DotenvFormat) {
+		d.keys.prefix = prefix
+	}
+}
+
+// WithDotenvDelimiter sets the delimiter used to split keys into key path
+// segments. The default is underscore ('_').
+func WithDotenvDelimiter(delim string) DotenvOption {
+	return func(d *DotenvFormat) {
+		d.keys.delimiter = delim
+	}
+}
+
+// WithDotenvTransform sets a custom transformation from a key (with the
+// prefix stripped) to a KeyPath. The delimiter is ignored.
+func WithDotenvTransform(fn func(string) config.KeyPath) DotenvOption {
+	if fn == nil {
+		panic("transform function cannot be nil")
+	}
+
+	return func(d *DotenvFormat) {
+		d.keys.transform = fn
+	}
+}
+
+// WithDotenvLookup sets the function used to resolve references to variables
+// that are not assigned in the file (default os.LookupEnv).
+func WithDotenvLookup(lookup func(name string) (string, bool)) DotenvOption {
+	if lookup == nil {
+		panic("lookup function cannot be nil")
+	}
+
+	return func(d *DotenvFormat) {
+		d.lookup = lookup
+	}
+}
+
+// NewDotenvFormat return new DotenvFormat object.
+func NewDotenvFormat(opts ...DotenvOption) Format {
+	format := DotenvFormat{
+		name:      "dotenv",
+		keepOrder: true,
+		data:      nil,
+		reader:    nil,
+		keys: flatKeys{
+			prefix:    "",
+			delimiter: "_",
+			lowercase: true,
+			transform: nil,
+		},
+		lookup: os.LookupEnv,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&format)
+		}
+	}
+
+	return format
+}
+
+// Name implements the Format interface.
+func (d DotenvFormat) Name() string {
+	return d.name
+}
+
+// KeepOrder implements the Format interface.
+func (d DotenvFormat) KeepOrder() bool {
+	return d.keepOrder
+}
+
+// From implements the Format interface.
+func (d DotenvFormat) From(reader io.Reader) Format {
+	d.reader = reader
+	return d
+}
+
+// Parse implements the Format interface.
+func (d DotenvFormat) Parse() (*tree.Node, error) {
+	if d.reader != nil {
+		dataFromReader, err := io.ReadAll(d.reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReader, err)
+		}
+
+		d.data = append(d.data, dataFromReader...)
+	}
+
+	if d.data == nil {
+		return nil, ErrNoData
+	}
+
+	parser := &dotenvParser{
+		data:   d.data,
+		pos:    0,
+		lines:  newLineIndex(d.data),
+		vars:   make(map[string]string),
+		lookup: d.lookup,
+	}
+
+	root := tree.New()
+
+	err := parser.parse(func(key, value string, rng tree.Range) {
+		d.keys.set(root, key, value, rng)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
+	}
+
+	return root, nil
+}
+
+// errDotenvSyntax is wrapped by every error of the dotenv parser.
+var errDotenvSyntax = errors.New("invalid dotenv")
+
+// dotenvParser parses a .env file.
+type dotenvParser struct {
+	data   []byte
+	pos    int
+	lines  lineIndex
+	vars   map[string]string
+	lookup func(name string) (string, bool)
+}
+
+func (p *dotenvParser) errorf(offset int, format string, args ...any) error {
+	return p.lines.errorf(errDotenvSyntax, offset, format, args...)
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+func (p *dotenvParser) skipSpace() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.pos++
+	}
+}
+
+// skipLineEnd skips an optional comment and the end of line.
+func (p *dotenvParser) skipLineEnd() error {
+	p.skipSpace()
+
+	if p.peek() == '#' {
+		for !p.eof() && p.data[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+
+	if p.peek() == '\r' {
+		p.pos++
+	}
+
+	if p.eof() {
+		return nil
+	}
+
+	if p.data[p.pos] != '\n' {
+		return p.errorf(p.pos, "unexpected %q after the value", p.data[p.pos])
+	}
+
+	p.pos++
+
+	return nil
+}
+
+// parse calls set for every assignment, in file order.
+func (p *dotenvParser) parse(set func(key, value string, rng tree.Range)) error {
+	for !p.eof() {
+		p.skipSpace()
+
+		if p.peek() != '#' && p.peek() != '\n' && p.peek() != '\r' && !p.eof() {
+			err := p.parseAssignment(set)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := p.skipLineEnd()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseAssignment parses a single "[export] KEY=value" line.
+func (p *dotenvParser) parseAssignment(set func(key, value string, rng tree.Range)) error {
+	if strings.HasPrefix(string(p.data[p.pos:]), "export") {
+		next := p.pos + len("export")
+		if next < len(p.data) && (p.data[next] == ' ' || p.data[next] == '\t') {
+			p.pos = next
+			p.skipSpace()
+		}
+	}
+
+	start := p.pos
+	for !p.eof() && isDotenvKeyChar(p.data[p.pos]) {
+		p.pos++
+	}
+
+	key := string(p.data[start:p.pos])
+	if key == "" {
+		return p.errorf(p.pos, "expected a variable name")
+	}
+
+	p.skipSpace()
+
+	if p.peek() != '=' {
+		return p.errorf(p.pos, "expected '=' after %q", key)
+	}
+
+	p.pos++
+	p.skipSpace()
+
+	valueStart := p.pos
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	p.vars[key] = value
+	set(key, value, p.lines.span(valueStart, p.pos-1))
+
+	return p.skipLineEnd()
+}
+
+func isDotenvKeyChar(char byte) bool {
+	return isBareKeyChar(char) || char == '.'
+}
+
+// parseValue parses an unquoted, single-quoted or double-quoted value.
+func (p *dotenvParser) parseValue() (string, error) {
+	switch p.peek() {
+	case '\'':
+		return p.parseSingleQuoted()
+	case '"':
+		return p.parseDoubleQuoted()
+	default:
+		return p.parseUnquoted()
+	}
+}
+
+// parseUnquoted parses a value up to the end of line or an inline comment.
+func (p *dotenvParser) parseUnquoted() (string, error) {
+	var builder strings.Builder
+
+	end := p.pos
+
+	for !p.eof() && p.data[p.pos] != '\n' {
+		char := p.data[p.pos]
+
+		switch {
+		case char == '#' && p.pos > 0 && (p.data[p.pos-1] == ' ' || p.data[p.pos-1] == '\t'):
+			p.pos = end
+
+			return strings.TrimRight(builder.String(), " \t\r"), nil
+		case char == '$':
+			err := p.expandReference(&builder)
+			if err != nil {
+				return "", err
+			}
+		default:
+			builder.WriteByte(char)
+			p.pos++
+		}
+
+		if char != ' ' && char != '\t' && char != '\r' {
+			end = p.pos
+		}
+	}
+
+	p.pos = end
+
+	return strings.TrimRight(builder.String(), " \t\r"), nil
+}
+
+// parseSingleQuoted parses a value taken literally up to the closing quote.
+func (p *dotenvParser) parseSingleQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	end := strings.IndexByte(string(p.data[p.pos:]), '\'')
+	if end < 0 {
+		return "", p.errorf(start, "unterminated single-quoted value")
+	}
+
+	value := string(p.data[p.pos : p.pos+end])
+	p.pos += end + 1
+
+	return value, nil
+}
+
+// parseDoubleQuoted parses a value with escapes and references up to the
+// closing quote.
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	var builder strings.Builder
+
+	start := p.pos
+	p.pos++
+
+	for {
+		if p.eof() {
+			return "", p.errorf(start, "unterminated double-quoted value")
+		}
+
+		switch char := p.data[p.pos]; char {
+		case '"':
+			p.pos++
+
+			return builder.String(), nil
+		case '\\':
+			p.parseEscape(&builder)
+		case '$':
+			err := p.expandReference(&builder)
+			if err != nil {
+				return "", err
+			}
+		default:
+			builder.WriteByte(char)
+			p.pos++
+		}
+	}
+}
+
+// parseEscape parses an escape sequence of a double-quoted value. Unknown
+// escapes are kept as they are.
+func (p *dotenvParser) parseEscape(builder *strings.Builder) {
+	p.pos++
+
+	if p.eof() {
+		builder.WriteByte('\\')
+
+		return
+	}
+
+	switch escape := p.data[p.pos]; escape {
+	case 'n':
+		builder.WriteByte('\n')
+	case 't':
+		builder.WriteByte('\t')
+	case 'r':
+		builder.WriteByte('\r')
+	case '"', '\\', '$':
+		builder.WriteByte(escape)
+	default:
+		builder.WriteByte('\\')
+		builder.WriteByte(escape)
+	}
+
+	p.pos++
+}
+
+// expandReference expands the $VAR or ${VAR} reference at the current
+// position. A '$' that does not start a reference is kept.
+func (p *dotenvParser) expandReference(builder *strings.Builder) error {
+	start := p.pos
+	p.pos++
+
+	if p.peek() != '{' {
+		nameStart := p.pos
+		for !p.eof() && (isBareKeyChar(p.data[p.pos]) && p.data[p.pos] != '-') {
+			p.pos++
+		}
+
+		if nameStart == p.pos {
+			builder.WriteByte('$')
+		} else {
+			builder.WriteString(p.resolve(string(p.data[nameStart:p.pos])))
+		}
+
+		return nil
+	}
+
+	end := strings.IndexByte(string(p.data[p.pos:]), '}')
+	if end < 0 {
+		return p.errorf(start, "unterminated variable reference")
+	}
+
+	ref := string(p.data[p.pos+1 : p.pos+end])
+	p.pos += end + 1
+
+	name, fallback, hasFallback := strings.Cut(ref, "-")
+	emptyFallback := strings.HasSuffix(name, ":")
+	name = strings.TrimSuffix(name, ":")
+
+	if name == "" {
+		return p.errorf(start, "empty variable reference")
+	}
+
+	value, ok := p.resolveVar(name)
+
+	switch {
+	case hasFallback && (!ok || emptyFallback && value == ""):
+		builder.WriteString(fallback)
+	default:
+		builder.WriteString(value)
+	}
+
+	return nil
+}
+
+// resolve returns the value of a variable, or an empty string.
+func (p *dotenvParser) resolve(name string) string {
+	value, _ := p.resolveVar(name)
+
+	return value
+}
+
+// resolveVar looks a variable up in the file, then with the lookup function.
+func (p *dotenvParser) resolveVar(name string) (string, bool) {
+	if value, ok := p.vars[name]; ok {
+		return value, true
+	}
+
+	return p.lookup(name)
+}
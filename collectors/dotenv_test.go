@@ -0,0 +1,138 @@
+package collectors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/tree"
+)
+
+const configDotenv = `# Local overrides.
+DB_HOST=localhost
+export DB_PORT = 5432   # inline comment
+DB_URL="postgres://${DB_HOST}:$DB_PORT/app"
+GREETING='Hello, ${NAME}'
+MULTI="line one
+line two\t\"quoted\" \$HOME"
+FALLBACK=${MISSING:-default}
+FROM_ENV=${HOME_DIR}/bin
+HASH=a#b
+EMPTY=
+`
+
+func fakeLookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+func TestNewDotenvFormat(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewDotenvFormat()
+
+	assert.Equal(t, "dotenv", format.Name())
+	assert.True(t, format.KeepOrder())
+}
+
+func TestDotenv_Parse(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewDotenvFormat(
+		collectors.WithDotenvLookup(fakeLookup(map[string]string{"HOME_DIR": "/home/dev"})))
+
+	root, err := format.From(strings.NewReader(configDotenv)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"db", "greeting", "multi", "fallback", "from", "hash", "empty"}, root.ChildrenKeys())
+	assert.Equal(t, "localhost", root.GetValue(config.NewKeyPath("db/host")))
+	assert.Equal(t, "5432", root.GetValue(config.NewKeyPath("db/port")))
+	assert.Equal(t, "postgres://localhost:5432/app", root.GetValue(config.NewKeyPath("db/url")))
+	assert.Equal(t, "Hello, ${NAME}", root.GetValue(config.NewKeyPath("greeting")))
+	assert.Equal(t, "line one\nline two\t\"quoted\" $HOME", root.GetValue(config.NewKeyPath("multi")))
+	assert.Equal(t, "default", root.GetValue(config.NewKeyPath("fallback")))
+	assert.Equal(t, "/home/dev/bin", root.GetValue(config.NewKeyPath("from/env")))
+	assert.Equal(t, "a#b", root.GetValue(config.NewKeyPath("hash")))
+	assert.Empty(t, root.GetValue(config.NewKeyPath("empty")))
+
+	assert.Equal(t, tree.NewRange(3, 18, 3, 21), root.Get(config.NewKeyPath("db/port")).Range)
+	assert.Equal(t, tree.NewRange(6, 7, 7, 28), root.Get(config.NewKeyPath("multi")).Range)
+}
+
+func TestDotenv_Parse_KeyMapping(t *testing.T) {
+	t.Parallel()
+
+	data := "APP__DB__HOST=h\nAPP__NAME=n\nOTHER=x\n"
+
+	root, err := collectors.NewDotenvFormat(
+		collectors.WithDotenvPrefix("APP__"), collectors.WithDotenvDelimiter("__")).
+		From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"db", "name"}, root.ChildrenKeys())
+	assert.Equal(t, "h", root.GetValue(config.NewKeyPath("db/host")))
+
+	root, err = collectors.NewDotenvFormat(
+		collectors.WithDotenvTransform(func(key string) config.KeyPath {
+			return config.NewKeyPath("custom/" + key)
+		})).
+		From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "x", root.GetValue(config.NewKeyPath("custom/OTHER")))
+}
+
+func TestDotenv_Parse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewDotenvFormat().From(nil).Parse()
+	require.Nil(t, root)
+	require.ErrorIs(t, err, collectors.ErrNoData)
+
+	for data, message := range map[string]string{
+		"KEY":                "line 1, column 4: expected '=' after \"KEY\"",
+		"=value":             "expected a variable name",
+		"A=1\nB=\"open":      "line 2, column 3: unterminated double-quoted value",
+		"A='open":            "unterminated single-quoted value",
+		"A=${B":              "unterminated variable reference",
+		"A=${}":              "empty variable reference",
+		"A=\"quoted\" extra": "unexpected 'e' after the value",
+	} {
+		root, err = collectors.NewDotenvFormat().From(strings.NewReader(data)).Parse()
+		require.Nil(t, root, data)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, data)
+		assert.Contains(t, err.Error(), message, data)
+	}
+}
+
+func TestDotenv_Source_Builder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("SERVER_PORT=8080\nSERVER_HOST=local\n"), 0o600))
+
+	collector, err := collectors.NewSource(context.Background(), collectors.NewFile(path),
+		collectors.NewDotenvFormat())
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"server": map[string]any{"port": 3301, "host": "prod"},
+	}))
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "local", config.MustGet[string](&cfg, config.NewKeyPath("server/host")))
+}
@@ -147,11 +147,20 @@ func (ec *Env) KeepOrder() bool {
 // defaultTransform converts an environment variable key to a hierarchical key path.
 // It lowercases the key, splits by the configured delimiter, removes empty parts, and joins with slash.
 func (ec *Env) defaultTransform(key string) config.KeyPath {
-	// Convert to lowercase.
-	key = strings.ToLower(key)
-	// Split by delimiter.
-	parts := strings.Split(key, ec.delimiter)
-	// Remove empty parts (if any).
+	return splitKey(strings.ToLower(key), ec.delimiter)
+}
+
+// stripPrefix removes the configured prefix from the environment variable key.
+// It returns the stripped key and true if the prefix matches, otherwise empty string and false.
+func (ec *Env) stripPrefix(key string) (string, bool) {
+	return stripKeyPrefix(key, ec.prefix)
+}
+
+// splitKey splits a flat key by delimiter into a key path, removing empty
+// parts.
+func splitKey(key, delimiter string) config.KeyPath {
+	parts := strings.Split(key, delimiter)
+
 	var filtered []string
 
 	for _, p := range parts {
@@ -163,14 +172,14 @@ func (ec *Env) defaultTransform(key string) config.KeyPath {
 	return config.NewKeyPathFromSegments(filtered)
 }
 
-// stripPrefix removes the configured prefix from the environment variable key.
-// It returns the stripped key and true if the prefix matches, otherwise empty string and false.
-func (ec *Env) stripPrefix(key string) (string, bool) {
+// stripKeyPrefix removes prefix from key. It returns the stripped key and
+// true if the prefix matches, otherwise empty string and false.
+func stripKeyPrefix(key, prefix string) (string, bool) {
 	switch {
-	case ec.prefix == "":
+	case prefix == "":
 		return key, true
-	case strings.HasPrefix(key, ec.prefix):
-		return strings.TrimPrefix(key, ec.prefix), true
+	case strings.HasPrefix(key, prefix):
+		return strings.TrimPrefix(key, prefix), true
 	default:
 		return "", false
 	}
@@ -3,6 +3,9 @@ package collectors
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/tarantool/go-storage/integrity"
 )
 
 var (
@@ -29,6 +32,24 @@ var (
 	// ErrNotStruct indicates that a value expected to be a struct (or a
 	// pointer to one) was something else.
 	ErrNotStruct = errors.New("value is not a struct")
+	// ErrUnknownFormat indicates that the format of a document could not be
+	// detected.
+	ErrUnknownFormat = errors.New("unknown format")
+	// ErrIncludeCycle indicates that a file includes itself, directly or
+	// through other files.
+	ErrIncludeCycle = errors.New("include cycle")
+	// ErrHTTPFetch indicates that fetching a document over HTTP failed.
+	ErrHTTPFetch = errors.New("http fetch failed")
+	// ErrFlagSet indicates that a value is not a supported flag set.
+	ErrFlagSet = errors.New("unsupported flag set")
+	// ErrStoragePublish indicates that publishing documents into a storage
+	// failed.
+	ErrStoragePublish = errors.New("storage publish failed")
+	// ErrCache indicates that reading or writing a cache file failed.
+	ErrCache = errors.New("cache file error")
+	// ErrRevisionConflict indicates that the data in a storage is not at the
+	// expected revision.
+	ErrRevisionConflict = errors.New("revision conflict")
 )
 
 // FormatParseError indicates that parsing a configuration value with the
@@ -55,3 +76,62 @@ func (e *FormatParseError) Error() string {
 func (e *FormatParseError) Unwrap() error {
 	return e.Err
 }
+
+// IntegrityError indicates that a storage document failed integrity
+// verification. Key is the storage key of the document, Failures lists the
+// failed checks, each naming the hasher or verifier (e.g.,
+// `hash mismatch for "sha256"` or `signature "RSASSA-PSS" not verified
+// (missing)`), and Err is the error reported by the integrity layer. It
+// matches ErrStorageValidation with errors.Is.
+type IntegrityError struct {
+	Key      string
+	Failures []string
+	Err      error
+}
+
+// NewIntegrityError builds an IntegrityError for the given storage key and
+// verification error of the integrity layer.
+func NewIntegrityError(key string, err error) *IntegrityError {
+	return &IntegrityError{Key: key, Failures: integrityFailures(err), Err: err}
+}
+
+// Error renders the key and the failed checks.
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("%v: key %q: %s", ErrStorageValidation, e.Key, strings.Join(e.Failures, "; "))
+}
+
+// Unwrap exposes ErrStorageValidation and the verification error.
+func (e *IntegrityError) Unwrap() []error {
+	return []error{ErrStorageValidation, e.Err}
+}
+
+// integrityFailures splits a verification error of the integrity layer into
+// one message per failed check, without the details following the check
+// (such as hex dumps of mismatching hashes).
+func integrityFailures(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	var aggregated *integrity.FailedToValidateAggregatedError
+	if errors.As(err, &aggregated) {
+		var failures []string
+		for _, inner := range aggregated.Unwrap() {
+			failures = append(failures, integrityFailures(inner)...)
+		}
+
+		return failures
+	}
+
+	var validation integrity.ValidationError
+	if !errors.As(err, &validation) {
+		return []string{err.Error()}
+	}
+
+	msg := validation.Error()
+	if detail := validation.Unpack(); detail != nil {
+		msg = strings.TrimSuffix(msg, ": "+detail.Error())
+	}
+
+	return []string{msg}
+}
@@ -0,0 +1,88 @@
+package collectors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/tarantool/go-config"
+)
+
+const (
+	// DefaultFilePollInterval is the interval at which [File.Watch] and
+	// [Directory.Watch] check the files for changes.
+	DefaultFilePollInterval = time.Second
+	// DefaultFileDebounce is how long changed content must stay the same
+	// before [File.Watch] and [Directory.Watch] report it, so that files are
+	// not reloaded while they are being written.
+	DefaultFileDebounce = 100 * time.Millisecond
+)
+
+// contentRevision returns the revision of file content: its SHA-256 hash.
+func contentRevision(data []byte) config.RevisionType {
+	sum := sha256.Sum256(data)
+
+	return config.RevisionType(hex.EncodeToString(sum[:]))
+}
+
+// pollChanges calls digest every interval and sends event, with the digest as
+// its revision, when the digest differs from the last reported one (initially
+// baseline) and has stayed the same for at least debounce. Failed digests are
+// skipped. The returned channel is closed when ctx is done.
+func pollChanges(
+	ctx context.Context,
+	interval, debounce time.Duration,
+	baseline config.RevisionType,
+	digest func() (config.RevisionType, error),
+	event WatchEvent,
+) <-chan WatchEvent {
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var (
+			pending      config.RevisionType
+			pendingSince time.Time
+		)
+
+		for {
+			var now time.Time
+
+			select {
+			case <-ctx.Done():
+				return
+			case now = <-ticker.C:
+			}
+
+			current, err := digest()
+			if err != nil || current == baseline {
+				pending = ""
+				continue
+			}
+
+			if current != pending {
+				pending, pendingSince = current, now
+			}
+
+			if now.Sub(pendingSince) < debounce {
+				continue
+			}
+
+			baseline, pending = current, ""
+			event.Revision = current
+
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+		}
+	}()
+
+	return eventCh
+}
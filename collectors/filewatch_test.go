@@ -0,0 +1,189 @@
+package collectors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+const testPollInterval = 10 * time.Millisecond
+
+func requireWatchEvent(t *testing.T, events <-chan collectors.WatchEvent) collectors.WatchEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "watch channel closed")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no watch event")
+	}
+
+	return collectors.WatchEvent{}
+}
+
+func requireNoWatchEvent(t *testing.T, events <-chan collectors.WatchEvent) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		require.FailNow(t, "unexpected watch event", "%+v", event)
+	case <-time.After(10 * testPollInterval):
+	}
+}
+
+// replaceFile replaces the file at path by an atomic rename.
+func replaceFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestFile_Watch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("level: info\n"), 0o600))
+
+	file := collectors.NewFile(path).WithPollInterval(testPollInterval).WithDebounce(0)
+
+	collector, err := collectors.NewSource(context.Background(), file, collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	revision := collector.Revision()
+	require.NotEmpty(t, revision)
+
+	watcher, ok := collector.(collectors.Watcher)
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := watcher.Watch(ctx)
+	require.NoError(t, err)
+
+	// Rewriting the same content is not a change.
+	replaceFile(t, path, "level: info\n")
+	requireNoWatchEvent(t, events)
+
+	replaceFile(t, path, "level: debug\n")
+
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, path, event.Prefix)
+	assert.NotEqual(t, revision, collector.Revision())
+	assert.Equal(t, collector.Revision(), event.Revision)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, "debug", config.MustGet[string](&cfg, config.NewKeyPath("level")))
+
+	// A file that is briefly missing is not reported until it is back.
+	require.NoError(t, os.Remove(path))
+	requireNoWatchEvent(t, events)
+
+	replaceFile(t, path, "level: warn\n")
+	requireWatchEvent(t, events)
+}
+
+func TestFile_Watch_Debounce(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1\n"), 0o600))
+
+	file := collectors.NewFile(path).WithPollInterval(testPollInterval).
+		WithDebounce(20 * testPollInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := file.Watch(ctx)
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 2\n"), 0o600))
+	requireWatchEvent(t, events)
+
+	assert.GreaterOrEqual(t, time.Since(start), 20*testPollInterval)
+}
+
+func TestDirectory_Watch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml", "a: 1\n")
+	writeTestFile(t, dir, "notes.txt", "ignored")
+
+	collector := collectors.NewDirectory(dir, ".yaml", collectors.NewYamlFormat()).
+		WithPollInterval(testPollInterval).WithDebounce(0)
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	revision := collector.Revision()
+	require.NotEmpty(t, revision)
+	assert.NotEqual(t, revision, subs[0].Revision())
+	assert.Len(t, subs[0].Revision(), 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := collector.Watch(ctx)
+	require.NoError(t, err)
+
+	writeTestFile(t, dir, "notes.txt", "changed")
+	requireNoWatchEvent(t, events)
+
+	replaceFile(t, filepath.Join(dir, "b.yaml"), "b: 2\n")
+
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, dir, event.Prefix)
+
+	replaceFile(t, filepath.Join(dir, "a.yaml"), "a: 3\n")
+	requireWatchEvent(t, events)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "b.yaml")))
+	requireWatchEvent(t, events)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, 3, config.MustGet[int](&cfg, config.NewKeyPath("a")))
+	assert.NotEqual(t, revision, collector.Revision())
+
+	_, ok := cfg.Lookup(config.NewKeyPath("b"))
+	assert.False(t, ok)
+}
+
+func TestDirectory_WithRevision_OverridesHash(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml", "a: 1\n")
+
+	collector := collectors.NewDirectory(dir, ".yaml", collectors.NewYamlFormat()).WithRevision("v1")
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	assert.Equal(t, config.RevisionType("v1"), collector.Revision())
+	assert.Equal(t, config.RevisionType("v1"), subs[0].Revision())
+}
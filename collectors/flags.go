@@ -0,0 +1,450 @@
+package collectors
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// flagInfo is the state of a single command-line flag.
+type flagInfo struct {
+	name     string
+	value    any
+	defValue any
+	changed  bool
+}
+
+// Flags reads configuration data from command-line flags.
+//
+// Only flags set explicitly on the command line are emitted, so unset flags
+// do not override values of other collectors with their defaults. The
+// defaults can be added as a separate, lowest-priority layer with
+// [Flags.Defaults].
+//
+// Flag names are split into key paths by the delimiters ("." and "-" by
+// default), so --iproto.listen and --iproto-listen both set iproto/listen.
+type Flags struct {
+	name       string
+	sourceType config.SourceType
+	revision   config.RevisionType
+	keepOrder  bool
+	visit      func(fn func(flagInfo))
+	defaults   bool
+	prefix     string
+	delimiters []string
+	transform  func(string) config.KeyPath
+}
+
+// NewFlags creates a Flags collector reading the given flag set. The flag set
+// is read on every Read, so it can be parsed after the collector is created.
+//
+// Values and defaults of flags implementing [flag.Getter] (all flags of the
+// standard types) are emitted typed, others as strings.
+func NewFlags(set *flag.FlagSet) *Flags {
+	return newFlags(func(fn func(flagInfo)) {
+		explicit := make(map[string]bool)
+
+		set.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+		set.VisitAll(func(f *flag.Flag) {
+			value := stdFlagValue(f.Value)
+
+			fn(flagInfo{
+				name:     f.Name,
+				value:    value,
+				defValue: parseFlagValue(f.DefValue, value),
+				changed:  explicit[f.Name],
+			})
+		})
+	})
+}
+
+// NewPFlags creates a Flags collector reading a flag set shaped like
+// *pflag.FlagSet of github.com/spf13/pflag, without depending on it: set
+// must have a VisitAll(func(*F)) method, where F is a struct with the
+// fields Name string, Value (a fmt.Stringer), DefValue string and
+// Changed bool. Values implementing GetSlice() []string (pflag slice flags)
+// are emitted as arrays. Values and defaults of flags whose Value has a
+// Type() string method naming a boolean, integer or float type (as pflag
+// does) are emitted typed, others as strings.
+//
+// Returns ErrFlagSet if set does not have this shape.
+func NewPFlags(set any) (*Flags, error) {
+	visit, err := pflagVisitor(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFlags(visit), nil
+}
+
+func newFlags(visit func(fn func(flagInfo))) *Flags {
+	return &Flags{
+		name:       "flags",
+		sourceType: config.FlagSource,
+		revision:   "",
+		keepOrder:  false,
+		visit:      visit,
+		defaults:   false,
+		prefix:     "",
+		delimiters: []string{".", "-"},
+		transform:  nil,
+	}
+}
+
+// WithName sets a custom name for the collector.
+func (fc *Flags) WithName(name string) *Flags {
+	fc.name = name
+	return fc
+}
+
+// WithSourceType sets the source type for the collector.
+func (fc *Flags) WithSourceType(source config.SourceType) *Flags {
+	fc.sourceType = source
+	return fc
+}
+
+// WithRevision sets the revision for the collector.
+func (fc *Flags) WithRevision(rev config.RevisionType) *Flags {
+	fc.revision = rev
+	return fc
+}
+
+// WithKeepOrder sets whether the collector preserves key order.
+func (fc *Flags) WithKeepOrder(keep bool) *Flags {
+	fc.keepOrder = keep
+	return fc
+}
+
+// WithPrefix sets a prefix to strip from flag names. If set, only flags
+// starting with this prefix are processed.
+func (fc *Flags) WithPrefix(prefix string) *Flags {
+	fc.prefix = prefix
+	return fc
+}
+
+// WithDelimiters sets the delimiters used to split flag names into key path
+// segments. The default is dot ('.') and dash ('-'); use WithDelimiters(".")
+// for keys that contain dashes themselves.
+func (fc *Flags) WithDelimiters(delims ...string) *Flags {
+	fc.delimiters = delims
+	return fc
+}
+
+// WithTransform sets a custom transformation function from flag name to
+// KeyPath. If set, prefix is still applied before transformation; delimiters
+// are ignored.
+func (fc *Flags) WithTransform(fn func(string) config.KeyPath) *Flags {
+	if fn == nil {
+		panic("transform function cannot be nil")
+	}
+
+	fc.transform = fn
+
+	return fc
+}
+
+// Defaults returns a collector emitting the default values of all flags,
+// with the same key mapping. It is named "<name>-default" and reports
+// config.FlagDefaultSource. Add it before the other collectors, so that it
+// has the lowest priority:
+//
+//	flags := collectors.NewFlags(flag.CommandLine)
+//	builder = builder.AddCollector(flags.Defaults())
+//	// ... files, environment ...
+//	builder = builder.AddCollector(flags)
+func (fc *Flags) Defaults() *Flags {
+	defaults := *fc
+	defaults.name = fc.name + "-default"
+	defaults.sourceType = config.FlagDefaultSource
+	defaults.defaults = true
+
+	return &defaults
+}
+
+// Read implements the Collector interface.
+func (fc *Flags) Read(ctx context.Context) <-chan config.Value {
+	valueCh := make(chan config.Value)
+
+	go func() {
+		defer close(valueCh)
+
+		root := tree.New()
+
+		fc.visit(func(info flagInfo) {
+			if !fc.defaults && !info.changed {
+				return
+			}
+
+			path, ok := fc.keyPath(info.name)
+			if !ok {
+				return
+			}
+
+			if fc.defaults {
+				setFlagValue(root, path, info.defValue)
+				return
+			}
+
+			setFlagValue(root, path, info.value)
+		})
+
+		// Skip if no flags matched — emitting an empty root node would
+		// overwrite all existing values during merge.
+		if !root.IsLeaf() {
+			walkTree(ctx, root, config.NewKeyPath(""), valueCh)
+		}
+	}()
+
+	return valueCh
+}
+
+// Name implements the Collector interface.
+func (fc *Flags) Name() string {
+	return fc.name
+}
+
+// Source implements the Collector interface.
+func (fc *Flags) Source() config.SourceType {
+	return fc.sourceType
+}
+
+// Revision implements the Collector interface.
+func (fc *Flags) Revision() config.RevisionType {
+	return fc.revision
+}
+
+// KeepOrder implements the Collector interface.
+func (fc *Flags) KeepOrder() bool {
+	return fc.keepOrder
+}
+
+// keyPath maps a flag name onto a key path.
+func (fc *Flags) keyPath(name string) (config.KeyPath, bool) {
+	name, ok := stripKeyPrefix(name, fc.prefix)
+	if !ok {
+		return nil, false
+	}
+
+	var path config.KeyPath
+
+	if fc.transform != nil {
+		path = fc.transform(name)
+	} else if len(fc.delimiters) > 0 {
+		for _, delim := range fc.delimiters[1:] {
+			name = strings.ReplaceAll(name, delim, fc.delimiters[0])
+		}
+
+		path = splitKey(name, fc.delimiters[0])
+	} else {
+		path = config.NewKeyPathFromSegments([]string{name})
+	}
+
+	return path, len(path) > 0
+}
+
+// setFlagValue stores a flag value at path; slices become arrays.
+func setFlagValue(root *tree.Node, path config.KeyPath, value any) {
+	items, ok := value.([]string)
+	if !ok {
+		root.Set(path, value)
+		return
+	}
+
+	// An empty list is kept as an empty array, so that it overrides a list
+	// of a lower-priority source.
+	if root.Get(path) == nil {
+		root.Set(path, nil)
+	}
+
+	for i, item := range items {
+		root.Set(path.Append(strconv.Itoa(i)), item)
+	}
+
+	root.Get(path).MarkArray()
+}
+
+// stdFlagValue returns the value of a standard flag: typed for the standard
+// flag types, a string otherwise.
+func stdFlagValue(value flag.Value) any {
+	getter, ok := value.(flag.Getter)
+	if !ok {
+		return value.String()
+	}
+
+	switch typed := getter.Get().(type) {
+	case bool, int, int64, uint, uint64, float64, string:
+		return typed
+	default:
+		return value.String()
+	}
+}
+
+// parseFlagValue parses the string form of a flag value, such as its
+// default, into the type of like. It returns str if like is not a boolean or
+// a number, or if str does not parse.
+func parseFlagValue(str string, like any) any {
+	var (
+		value any
+		err   error
+	)
+
+	switch like.(type) {
+	case bool:
+		value, err = strconv.ParseBool(str)
+	case int:
+		value, err = strconv.Atoi(str)
+	case int64:
+		value, err = strconv.ParseInt(str, 0, 64)
+	case uint:
+		var parsed uint64
+
+		parsed, err = strconv.ParseUint(str, 0, strconv.IntSize)
+		value = uint(parsed)
+	case uint64:
+		value, err = strconv.ParseUint(str, 0, 64)
+	case float64:
+		value, err = strconv.ParseFloat(str, 64)
+	default:
+		return str
+	}
+
+	if err != nil {
+		return str
+	}
+
+	return value
+}
+
+// pflagKind returns a value of the type a pflag value of the given Type() is
+// emitted as, or an empty string for the types emitted as strings.
+func pflagKind(typ string) any {
+	switch typ {
+	case "bool":
+		return false
+	case "int", "count":
+		return 0
+	case "int8", "int16", "int32", "int64":
+		return int64(0)
+	case "uint":
+		return uint(0)
+	case "uint8", "uint16", "uint32", "uint64":
+		return uint64(0)
+	case "float32", "float64":
+		return float64(0)
+	default:
+		return ""
+	}
+}
+
+// parsePFlagSlice parses the string form of a pflag slice value, a
+// bracketed comma-separated list such as "[a,b]".
+func parsePFlagSlice(str string) []string {
+	str = strings.TrimSuffix(strings.TrimPrefix(str, "["), "]")
+	if str == "" {
+		return []string{}
+	}
+
+	items, err := csv.NewReader(strings.NewReader(str)).Read()
+	if err != nil {
+		return strings.Split(str, ",")
+	}
+
+	return items
+}
+
+// pflagVisitor returns a visit function over a pflag-like flag set.
+func pflagVisitor(set any) (func(fn func(flagInfo)), error) {
+	setValue := reflect.ValueOf(set)
+	if !setValue.IsValid() {
+		return nil, fmt.Errorf("%w: nil", ErrFlagSet)
+	}
+
+	visitAll := setValue.MethodByName("VisitAll")
+	if !visitAll.IsValid() {
+		return nil, fmt.Errorf("%w: %T has no VisitAll method", ErrFlagSet, set)
+	}
+
+	visitType := visitAll.Type()
+	if visitType.NumIn() != 1 || visitType.NumOut() != 0 || visitType.In(0).Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w: %T.VisitAll is not func(func(*Flag))", ErrFlagSet, set)
+	}
+
+	fnType := visitType.In(0)
+	if fnType.NumIn() != 1 || fnType.NumOut() != 0 || fnType.In(0).Kind() != reflect.Pointer ||
+		!isPFlagStruct(fnType.In(0).Elem()) {
+		return nil, fmt.Errorf("%w: %T.VisitAll is not func(func(*Flag))", ErrFlagSet, set)
+	}
+
+	return func(fn func(flagInfo)) {
+		callback := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+			fn(pflagInfo(args[0].Elem()))
+
+			return nil
+		})
+
+		visitAll.Call([]reflect.Value{callback})
+	}, nil
+}
+
+// isPFlagStruct reports whether typ has the fields of pflag.Flag read by
+// Flags.
+func isPFlagStruct(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	stringType := reflect.TypeFor[string]()
+	stringerType := reflect.TypeFor[fmt.Stringer]()
+
+	name, hasName := typ.FieldByName("Name")
+	value, hasValue := typ.FieldByName("Value")
+	defValue, hasDefValue := typ.FieldByName("DefValue")
+	changed, hasChanged := typ.FieldByName("Changed")
+
+	return hasName && name.Type == stringType &&
+		hasValue && value.Type.Implements(stringerType) &&
+		hasDefValue && defValue.Type == stringType &&
+		hasChanged && changed.Type == reflect.TypeFor[bool]()
+}
+
+// pflagInfo reads a pflag.Flag-like struct.
+func pflagInfo(flagStruct reflect.Value) flagInfo {
+	defValue := flagStruct.FieldByName("DefValue").String()
+
+	info := flagInfo{
+		name:     flagStruct.FieldByName("Name").String(),
+		value:    nil,
+		defValue: defValue,
+		changed:  flagStruct.FieldByName("Changed").Bool(),
+	}
+
+	value := flagStruct.FieldByName("Value")
+	if value.IsNil() {
+		return info
+	}
+
+	switch typed := value.Interface().(type) {
+	case interface{ GetSlice() []string }:
+		info.value = typed.GetSlice()
+		info.defValue = parsePFlagSlice(defValue)
+	case interface {
+		fmt.Stringer
+		Type() string
+	}:
+		kind := pflagKind(typed.Type())
+		info.value = parseFlagValue(typed.String(), kind)
+		info.defValue = parseFlagValue(defValue, kind)
+	case fmt.Stringer:
+		info.value = typed.String()
+	}
+
+	return info
+}
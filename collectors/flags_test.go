@@ -0,0 +1,273 @@
+package collectors_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+func newTestFlagSet(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String("iproto.listen", "localhost:3301", "listen address")
+	set.Int("iproto-threads", 1, "number of threads")
+	set.Bool("debug", false, "debug mode")
+	set.String("log.level", "info", "log level")
+
+	require.NoError(t, set.Parse(args))
+
+	return set
+}
+
+func readFlagValues(t *testing.T, collector config.Collector) map[string]any {
+	t.Helper()
+
+	values := make(map[string]any)
+
+	for value := range collector.Read(context.Background()) {
+		var dest any
+		require.NoError(t, value.Get(&dest))
+
+		values[value.Meta().Key.String()] = dest
+	}
+
+	return values
+}
+
+func TestFlags_Read(t *testing.T) {
+	t.Parallel()
+
+	set := newTestFlagSet(t, "--iproto.listen=0.0.0.0:3301", "--iproto-threads", "4", "--debug")
+
+	collector := collectors.NewFlags(set)
+	assert.Equal(t, "flags", collector.Name())
+	assert.Equal(t, config.FlagSource, collector.Source())
+	assert.Equal(t, config.RevisionType(""), collector.Revision())
+	assert.False(t, collector.KeepOrder())
+
+	assert.Equal(t, map[string]any{
+		"iproto/listen":  "0.0.0.0:3301",
+		"iproto/threads": 4,
+		"debug":          true,
+	}, readFlagValues(t, collector))
+}
+
+func TestFlags_NothingSet(t *testing.T) {
+	t.Parallel()
+
+	collector := collectors.NewFlags(newTestFlagSet(t))
+	assert.Empty(t, readFlagValues(t, collector))
+}
+
+func TestFlags_WithPrefixAndDelimiters(t *testing.T) {
+	t.Parallel()
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String("app.http-port", "", "")
+	set.String("other", "", "")
+	require.NoError(t, set.Parse([]string{"--app.http-port=8080", "--other=x"}))
+
+	collector := collectors.NewFlags(set).WithPrefix("app.").WithDelimiters(".")
+	assert.Equal(t, map[string]any{"http-port": "8080"}, readFlagValues(t, collector))
+}
+
+func TestFlags_WithTransform(t *testing.T) {
+	t.Parallel()
+
+	set := newTestFlagSet(t, "--log.level=debug")
+
+	collector := collectors.NewFlags(set).WithTransform(func(name string) config.KeyPath {
+		return config.NewKeyPathFromSegments([]string{"cli", strings.ToUpper(name)})
+	})
+	assert.Equal(t, map[string]any{"cli/LOG.LEVEL": "debug"}, readFlagValues(t, collector))
+
+	assert.Panics(t, func() { collectors.NewFlags(set).WithTransform(nil) })
+}
+
+func TestFlags_Defaults(t *testing.T) {
+	t.Parallel()
+
+	set := newTestFlagSet(t, "--iproto-threads=8")
+	flags := collectors.NewFlags(set)
+
+	defaults := flags.Defaults()
+	assert.Equal(t, "flags-default", defaults.Name())
+	assert.Equal(t, config.FlagDefaultSource, defaults.Source())
+	assert.Equal(t, "flags", flags.Name())
+
+	// Defaults are typed like the flags.
+	assert.Equal(t, map[string]any{
+		"iproto/listen":  "localhost:3301",
+		"iproto/threads": 1,
+		"debug":          false,
+		"log/level":      "info",
+	}, readFlagValues(t, defaults))
+
+	file := collectors.NewMap(map[string]any{
+		"iproto": map[string]any{"listen": "file:3301"},
+	}).WithName("file")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(defaults)
+	builder = builder.AddCollector(file)
+	builder = builder.AddCollector(flags)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, "file:3301", config.MustGet[string](&cfg, config.NewKeyPath("iproto/listen")))
+	assert.Equal(t, 8, config.MustGet[int](&cfg, config.NewKeyPath("iproto/threads")))
+	assert.Equal(t, "info", config.MustGet[string](&cfg, config.NewKeyPath("log/level")))
+
+	for path, source := range map[string]string{
+		"iproto/listen":  "file",
+		"iproto/threads": "flags",
+		"log/level":      "flags-default",
+	} {
+		value, ok := cfg.Lookup(config.NewKeyPath(path))
+		require.True(t, ok, path)
+		assert.Equal(t, source, value.Meta().Source.Name, path)
+	}
+}
+
+type fakePFlagValue struct {
+	value string
+}
+
+func (v *fakePFlagValue) String() string { return v.value }
+
+type fakePFlagTypedValue struct {
+	fakePFlagValue
+
+	typ string
+}
+
+func (v *fakePFlagTypedValue) Type() string { return v.typ }
+
+type fakePFlagSliceValue struct {
+	fakePFlagValue
+
+	items []string
+}
+
+func (v *fakePFlagSliceValue) GetSlice() []string { return v.items }
+
+type fakePFlag struct {
+	Name     string
+	Value    fmt.Stringer
+	DefValue string
+	Changed  bool
+}
+
+type fakePFlagSet struct {
+	flags []*fakePFlag
+}
+
+func (s *fakePFlagSet) VisitAll(fn func(*fakePFlag)) {
+	for _, f := range s.flags {
+		fn(f)
+	}
+}
+
+func TestNewPFlags(t *testing.T) {
+	t.Parallel()
+
+	set := &fakePFlagSet{flags: []*fakePFlag{
+		{Name: "iproto-listen", Value: &fakePFlagValue{value: "0.0.0.0:3301"}, DefValue: "localhost", Changed: true},
+		{Name: "log.level", Value: &fakePFlagValue{value: "info"}, DefValue: "info", Changed: false},
+		{
+			Name:     "roles",
+			Value:    &fakePFlagSliceValue{fakePFlagValue: fakePFlagValue{value: "[a,b]"}, items: []string{"a", "b"}},
+			DefValue: "[storage]",
+			Changed:  true,
+		},
+		{
+			Name:     "iproto.threads",
+			Value:    &fakePFlagTypedValue{fakePFlagValue: fakePFlagValue{value: "4"}, typ: "int"},
+			DefValue: "1",
+			Changed:  true,
+		},
+		{
+			Name:     "ratio",
+			Value:    &fakePFlagTypedValue{fakePFlagValue: fakePFlagValue{value: "0.5"}, typ: "float64"},
+			DefValue: "0.25",
+			Changed:  false,
+		},
+		{
+			Name:     "debug",
+			Value:    &fakePFlagTypedValue{fakePFlagValue: fakePFlagValue{value: "false"}, typ: "bool"},
+			DefValue: "false",
+			Changed:  false,
+		},
+	}}
+
+	collector, err := collectors.NewPFlags(set)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"iproto/listen":  "0.0.0.0:3301",
+		"iproto/threads": 4,
+		"roles/0":        "a",
+		"roles/1":        "b",
+	}, readFlagValues(t, collector))
+
+	assert.Equal(t, map[string]any{
+		"iproto/listen":  "localhost",
+		"iproto/threads": 1,
+		"log/level":      "info",
+		"roles/0":        "storage",
+		"ratio":          0.25,
+		"debug":          false,
+	}, readFlagValues(t, collector.Defaults()))
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, []string{"a", "b"}, config.MustGet[[]string](&cfg, config.NewKeyPath("roles")))
+}
+
+func TestNewPFlags_EmptySlice(t *testing.T) {
+	t.Parallel()
+
+	set := &fakePFlagSet{flags: []*fakePFlag{{
+		Name:     "roles",
+		Value:    &fakePFlagSliceValue{fakePFlagValue: fakePFlagValue{value: "[]"}, items: []string{}},
+		DefValue: "[]",
+		Changed:  true,
+	}}}
+
+	collector, err := collectors.NewPFlags(set)
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"roles": []any{"storage", "router"}}))
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Empty(t, config.MustGet[[]string](&cfg, config.NewKeyPath("roles")))
+}
+
+func TestNewPFlags_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, set := range []any{
+		nil,
+		42,
+		flag.NewFlagSet("test", flag.ContinueOnError),
+	} {
+		_, err := collectors.NewPFlags(set)
+		require.ErrorIs(t, err, collectors.ErrFlagSet)
+	}
+}
@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// flatKeys maps the flat keys of key/value formats (dotenv, properties) onto
+// key paths the way Env does: the prefix is stripped, then the key is split
+// by the delimiter, or passed to a custom transformation.
+type flatKeys struct {
+	prefix    string
+	delimiter string
+	lowercase bool
+	transform func(string) config.KeyPath
+}
+
+// keyPath returns the key path for key, or false if the key does not match
+// the prefix or maps to an empty path.
+func (f flatKeys) keyPath(key string) (config.KeyPath, bool) {
+	key, ok := stripKeyPrefix(key, f.prefix)
+	if !ok {
+		return nil, false
+	}
+
+	var path config.KeyPath
+
+	switch {
+	case f.transform != nil:
+		path = f.transform(key)
+	case f.lowercase:
+		path = splitKey(strings.ToLower(key), f.delimiter)
+	default:
+		path = splitKey(key, f.delimiter)
+	}
+
+	return path, len(path) > 0
+}
+
+// set stores value at the key path of key, with the given source range.
+func (f flatKeys) set(root *tree.Node, key string, value any, rng tree.Range) {
+	path, ok := f.keyPath(key)
+	if !ok {
+		return
+	}
+
+	root.Set(path, value)
+	root.Get(path).Range = rng
+}
@@ -13,3 +13,35 @@ type Format interface {
 	From(r io.Reader) Format
 	Parse() (*tree.Node, error)
 }
+
+// MultiDocumentFormat is implemented by formats whose input may hold several
+// documents, such as a YAML stream separated by "---". ParseDocuments returns
+// one tree per document, in stream order.
+type MultiDocumentFormat interface {
+	Format
+	ParseDocuments() ([]*tree.Node, error)
+}
+
+// namedReader is a reader of a named file. Formats use the name to detect
+// the format by extension or to resolve paths relative to the file, like
+// with an *os.File.
+type namedReader struct {
+	io.Reader
+
+	name string
+}
+
+// Name returns the name of the file.
+func (r namedReader) Name() string {
+	return r.name
+}
+
+// readerName returns the file name of a reader with a Name method (such as
+// *os.File), or an empty string.
+func readerName(reader io.Reader) string {
+	if named, ok := reader.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+
+	return ""
+}
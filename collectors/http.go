@@ -0,0 +1,318 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tarantool/go-config"
+)
+
+const (
+	// DefaultHTTPPollInterval is the interval at which [HTTP.Watch] polls the
+	// URL for changes.
+	DefaultHTTPPollInterval = 30 * time.Second
+	// DefaultHTTPRetryBackoff is the delay before the first retry of a failed
+	// request; it doubles with every following retry.
+	DefaultHTTPRetryBackoff = 500 * time.Millisecond
+
+	defaultHTTPTimeout = 30 * time.Second
+)
+
+//nolint:gochecknoglobals,exhaustruct // package-private default client, never mutated
+var defaultHTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+
+// HTTP implements DataSource with a document fetched over HTTP(S).
+//
+// Requests are conditional: once a document was fetched, its ETag and
+// Last-Modified validators are sent as If-None-Match and If-Modified-Since,
+// and a 304 Not Modified response serves the cached document. The ETag (or
+// Last-Modified, if the server sends no ETag) is reported as the revision.
+//
+// HTTP implements [Watcher] by polling the URL, so a collector created with
+// [NewSource] is reloaded by a [config.Reloader] when the document changes.
+// The stream returned by FetchStream has a ContentType method, which lets
+// [AutoFormat] detect the format from the Content-Type header.
+type HTTP struct {
+	name       string
+	sourceType config.SourceType
+	url        string
+	client     *http.Client
+	header     http.Header
+	interval   time.Duration
+	retries    int
+	backoff    time.Duration
+
+	mu           sync.RWMutex
+	fetched      bool
+	body         []byte
+	contentType  string
+	etag         string
+	lastModified string
+}
+
+// NewHTTP returns a new HTTP data source for the given URL.
+func NewHTTP(url string) *HTTP {
+	return &HTTP{
+		name:         "http",
+		sourceType:   config.HTTPSource,
+		url:          url,
+		client:       nil,
+		header:       make(http.Header),
+		interval:     DefaultHTTPPollInterval,
+		retries:      0,
+		backoff:      DefaultHTTPRetryBackoff,
+		mu:           sync.RWMutex{},
+		fetched:      false,
+		body:         nil,
+		contentType:  "",
+		etag:         "",
+		lastModified: "",
+	}
+}
+
+// WithName sets a custom name for the source.
+func (h *HTTP) WithName(name string) *HTTP {
+	h.name = name
+	return h
+}
+
+// WithSourceType sets the source type (config.HTTPSource by default).
+func (h *HTTP) WithSourceType(source config.SourceType) *HTTP {
+	h.sourceType = source
+	return h
+}
+
+// WithHTTPClient injects the HTTP client used for requests. If unset, a
+// package-private default client with a 30-second timeout is used.
+func (h *HTTP) WithHTTPClient(client *http.Client) *HTTP {
+	h.client = client
+	return h
+}
+
+// WithHeader adds a header sent with every request, such as Authorization.
+func (h *HTTP) WithHeader(key, value string) *HTTP {
+	h.header.Add(key, value)
+	return h
+}
+
+// WithPollInterval sets the interval at which Watch polls the URL (default
+// [DefaultHTTPPollInterval]). A value <= 0 restores the default.
+func (h *HTTP) WithPollInterval(interval time.Duration) *HTTP {
+	if interval <= 0 {
+		interval = DefaultHTTPPollInterval
+	}
+
+	h.interval = interval
+
+	return h
+}
+
+// WithRetries sets how many times a failed request is retried: on transport
+// errors, 5xx and 429 responses. The first retry waits backoff (or
+// [DefaultHTTPRetryBackoff] if backoff <= 0), and the delay doubles with every
+// following retry. No retries are made by default.
+func (h *HTTP) WithRetries(retries int, backoff time.Duration) *HTTP {
+	if backoff <= 0 {
+		backoff = DefaultHTTPRetryBackoff
+	}
+
+	h.retries = retries
+	h.backoff = backoff
+
+	return h
+}
+
+// Name returns name of the source.
+func (h *HTTP) Name() string {
+	return h.name
+}
+
+// SourceType returns source type.
+func (h *HTTP) SourceType() config.SourceType {
+	return h.sourceType
+}
+
+// Revision returns the ETag of the last fetched document, or its
+// Last-Modified time if the server sent no ETag.
+func (h *HTTP) Revision() config.RevisionType {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.etag != "" {
+		return config.RevisionType(h.etag)
+	}
+
+	return config.RevisionType(h.lastModified)
+}
+
+// FetchStream performs a conditional GET of the URL and returns a reader over
+// the document; on 304 Not Modified the cached document is returned. Failed
+// requests are retried as configured with WithRetries, and errors are wrapped
+// with ErrHTTPFetch.
+func (h *HTTP) FetchStream(ctx context.Context) (io.ReadCloser, error) {
+	_, err := h.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return &httpBody{Reader: bytes.NewReader(h.body), contentType: h.contentType}, nil
+}
+
+// Watch implements the Watcher interface. It polls the URL at the configured
+// interval and sends an event when the document changes. Failed polls are
+// skipped; the document is polled again at the next tick.
+func (h *HTTP) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changed, err := h.fetch(ctx)
+			if err != nil || !changed {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- WatchEvent{Prefix: h.url, Keys: nil, Revision: h.Revision()}:
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
+
+// fetch requests the document, retrying failed requests with backoff. It
+// reports whether a previously fetched document has changed.
+func (h *HTTP) fetch(ctx context.Context) (bool, error) {
+	backoff := h.backoff
+
+	for attempt := 0; ; attempt++ {
+		changed, retry, err := h.fetchOnce(ctx)
+		if err == nil {
+			return changed, nil
+		}
+
+		if !retry || attempt >= h.retries {
+			return false, err
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return false, fmt.Errorf("%w: %w", ErrHTTPFetch, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// fetchOnce performs a single conditional request. It reports whether a
+// previously fetched document has changed and whether a failed request may be
+// retried.
+func (h *HTTP) fetchOnce(ctx context.Context) (bool, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return false, false, fmt.Errorf("%w: %w", ErrHTTPFetch, err)
+	}
+
+	req.Header.Set("User-Agent", "go-config")
+
+	for key, values := range h.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	h.mu.RLock()
+	fetched := h.fetched
+
+	if fetched && h.etag != "" {
+		req.Header.Set("If-None-Match", h.etag)
+	}
+
+	if fetched && h.lastModified != "" {
+		req.Header.Set("If-Modified-Since", h.lastModified)
+	}
+
+	h.mu.RUnlock()
+
+	client := h.client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, ctx.Err() == nil, fmt.Errorf("%w: %w", ErrHTTPFetch, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && fetched:
+		return false, false, nil
+	case resp.StatusCode == http.StatusOK:
+	default:
+		retry := resp.StatusCode >= http.StatusInternalServerError ||
+			resp.StatusCode == http.StatusTooManyRequests
+
+		return false, retry, fmt.Errorf("%w: %s: unexpected status %s", ErrHTTPFetch, h.url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, true, fmt.Errorf("%w: %w", ErrHTTPFetch, err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := fetched && !bytes.Equal(data, h.body)
+
+	h.fetched = true
+	h.body = data
+	h.contentType = resp.Header.Get("Content-Type")
+	h.etag = resp.Header.Get("ETag")
+	h.lastModified = resp.Header.Get("Last-Modified")
+
+	return changed, false, nil
+}
+
+// httpBody is a fetched document with the Content-Type it was served with.
+type httpBody struct {
+	*bytes.Reader
+
+	contentType string
+}
+
+// ContentType returns the Content-Type header of the response.
+func (b *httpBody) ContentType() string {
+	return b.contentType
+}
+
+// Close implements io.Closer.
+func (b *httpBody) Close() error {
+	return nil
+}
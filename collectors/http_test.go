@@ -0,0 +1,219 @@
+package collectors_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+// configServer serves a YAML document with an ETag and honors If-None-Match.
+type configServer struct {
+	mu       sync.Mutex
+	body     string
+	etag     string
+	requests atomic.Int32
+	notMod   atomic.Int32
+}
+
+func (s *configServer) set(body, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.body = body
+	s.etag = etag
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+
+	s.mu.Lock()
+	body, etag := s.body, s.etag
+	s.mu.Unlock()
+
+	if r.Header.Get("If-None-Match") == etag {
+		s.notMod.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = io.WriteString(w, body)
+}
+
+func TestHTTP_FetchStream(t *testing.T) {
+	t.Parallel()
+
+	server := &configServer{}
+	server.set("server:\n  port: 3301\n", `"v1"`)
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	source := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client())
+	assert.Equal(t, "http", source.Name())
+	assert.Equal(t, config.HTTPSource, source.SourceType())
+	assert.Empty(t, source.Revision())
+
+	for range 2 {
+		reader, err := source.FetchStream(context.Background())
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		assert.Equal(t, "server:\n  port: 3301\n", string(data))
+		assert.Equal(t, config.RevisionType(`"v1"`), source.Revision())
+	}
+
+	assert.Equal(t, int32(2), server.requests.Load())
+	assert.Equal(t, int32(1), server.notMod.Load())
+
+	server.set("server:\n  port: 3302\n", `"v2"`)
+
+	collector, err := collectors.NewSource(context.Background(), source, collectors.NewAutoFormat(nil))
+	require.NoError(t, err)
+	assert.Equal(t, config.RevisionType(`"v2"`), collector.Revision())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, 3302, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+}
+
+func TestHTTP_LastModified(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		if r.Header.Get("If-Modified-Since") == modified.Format(http.TimeFormat) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+		_, _ = io.WriteString(w, `{"a": 1}`)
+	}))
+	defer ts.Close()
+
+	source := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client()).
+		WithHeader("Authorization", "Bearer token")
+
+	for range 2 {
+		reader, err := source.FetchStream(context.Background())
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a": 1}`, string(data))
+	}
+
+	assert.Equal(t, config.RevisionType(modified.Format(http.TimeFormat)), source.Revision())
+}
+
+func TestHTTP_Retries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = io.WriteString(w, "a: 1\n")
+	}))
+	defer ts.Close()
+
+	_, err := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client()).
+		FetchStream(context.Background())
+	require.ErrorIs(t, err, collectors.ErrHTTPFetch)
+	assert.Contains(t, err.Error(), "503")
+
+	requests.Store(0)
+
+	reader, err := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client()).
+		WithRetries(2, time.Millisecond).FetchStream(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestHTTP_NoRetryOnClientError(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	_, err := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client()).
+		WithRetries(3, time.Millisecond).FetchStream(context.Background())
+	require.ErrorIs(t, err, collectors.ErrHTTPFetch)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestHTTP_Watch(t *testing.T) {
+	t.Parallel()
+
+	server := &configServer{}
+	server.set("level: info\n", `"v1"`)
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	source := collectors.NewHTTP(ts.URL).WithHTTPClient(ts.Client()).
+		WithPollInterval(10 * time.Millisecond)
+
+	collector, err := collectors.NewSource(context.Background(), source, collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	watcher, ok := collector.(collectors.Watcher)
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := watcher.Watch(ctx)
+	require.NoError(t, err)
+
+	server.set("level: debug\n", `"v2"`)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, ts.URL, event.Prefix)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no watch event")
+	}
+
+	assert.Equal(t, config.RevisionType(`"v2"`), collector.Revision())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+	assert.Equal(t, "debug", config.MustGet[string](&cfg, config.NewKeyPath("level")))
+}
@@ -0,0 +1,281 @@
+package collectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// JSONFormat implements Format interface.
+//
+// Object keys keep the order in which they appear in the document, arrays
+// are marked on the resulting tree.Node and every value gets a tree.Range
+// pointing at its position in the document.
+type JSONFormat struct {
+	name      string
+	keepOrder bool
+	data      []byte
+	reader    io.Reader
+}
+
+// NewJSONFormat return new JSONFormat object.
+func NewJSONFormat() Format {
+	return JSONFormat{
+		name:      "json",
+		keepOrder: true,
+		data:      nil,
+		reader:    nil,
+	}
+}
+
+// Name implements the Format interface.
+func (j JSONFormat) Name() string {
+	return j.name
+}
+
+// KeepOrder implements the Format interface.
+func (j JSONFormat) KeepOrder() bool {
+	return j.keepOrder
+}
+
+// From implements the Format interface.
+func (j JSONFormat) From(reader io.Reader) Format {
+	j.reader = reader
+	return j
+}
+
+// Parse implements the Format interface.
+func (j JSONFormat) Parse() (*tree.Node, error) {
+	if j.reader != nil {
+		dataFromReader, err := io.ReadAll(j.reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReader, err)
+		}
+
+		j.data = append(j.data, dataFromReader...)
+	}
+
+	if j.data == nil {
+		return nil, ErrNoData
+	}
+
+	parser := newJSONParser(j.data)
+	root := tree.New()
+
+	err := parser.parseValue(root, config.NewKeyPath(""))
+	if err == nil {
+		err = parser.parseEOF()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, parser.locate(err))
+	}
+
+	return root, nil
+}
+
+// errJSONTrailingData is reported when a document holds more than one value.
+var errJSONTrailingData = errors.New("invalid character after top-level value")
+
+// jsonParser walks the token stream of a JSON document and builds a tree,
+// tracking the position of every token.
+type jsonParser struct {
+	data    []byte
+	decoder *json.Decoder
+	lines   lineIndex
+}
+
+func newJSONParser(data []byte) *jsonParser {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return &jsonParser{
+		data:    data,
+		decoder: decoder,
+		lines:   newLineIndex(data),
+	}
+}
+
+// tokenStart returns the offset of the next token: the decoder's offset
+// points right after the previous token, before the separators.
+func (p *jsonParser) tokenStart() int {
+	offset := int(p.decoder.InputOffset())
+
+	for offset < len(p.data) {
+		switch p.data[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+// tokenRange returns the range between start and the end of the last token.
+func (p *jsonParser) tokenRange(start int) tree.Range {
+	return p.lines.span(start, int(p.decoder.InputOffset())-1)
+}
+
+// locate adds the line and column to a syntax error.
+func (p *jsonParser) locate(err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	pos := p.lines.position(max(int(syntaxErr.Offset)-1, 0))
+
+	return fmt.Errorf("line %d, column %d: %w", pos.Line, pos.Column, err)
+}
+
+// parseValue reads a single value and stores it at prefix.
+func (p *jsonParser) parseValue(node *tree.Node, prefix config.KeyPath) error {
+	start := p.tokenStart()
+
+	token, err := p.decoder.Token()
+	if err != nil {
+		return p.tokenError(err)
+	}
+
+	switch token {
+	case json.Delim('{'):
+		return p.parseObject(node, prefix, start)
+	case json.Delim('['):
+		return p.parseArray(node, prefix, start)
+	}
+
+	node.Set(prefix, resolveJSONScalar(token))
+
+	if target := node.Get(prefix); target != nil {
+		target.Range = p.tokenRange(start)
+	}
+
+	return nil
+}
+
+// parseObject reads the members of an object whose '{' starts at start.
+func (p *jsonParser) parseObject(node *tree.Node, prefix config.KeyPath, start int) error {
+	// Ensure the mapping node exists so that empty objects are kept.
+	if node.Get(prefix) == nil {
+		node.Set(prefix, nil)
+	}
+
+	empty := true
+
+	for p.decoder.More() {
+		empty = false
+
+		token, err := p.decoder.Token()
+		if err != nil {
+			return p.tokenError(err)
+		}
+
+		// The decoder guarantees that object keys are strings.
+		key, _ := token.(string)
+
+		// A duplicate key replaces the earlier value, as in encoding/json.
+		node.Get(prefix).DeleteChild(key)
+
+		err = p.parseValue(node, prefix.Append(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := p.decoder.Token()
+	if err != nil {
+		return p.tokenError(err)
+	}
+
+	target := node.Get(prefix)
+	if empty {
+		target.Value = map[string]any{}
+	}
+
+	target.Range = p.tokenRange(start)
+
+	return nil
+}
+
+// parseArray reads the items of an array whose '[' starts at start.
+func (p *jsonParser) parseArray(node *tree.Node, prefix config.KeyPath, start int) error {
+	// Ensure the array node exists even for empty arrays.
+	if node.Get(prefix) == nil {
+		node.Set(prefix, nil)
+	}
+
+	node.Get(prefix).MarkArray()
+
+	for i := 0; p.decoder.More(); i++ {
+		err := p.parseValue(node, prefix.Append(strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := p.decoder.Token()
+	if err != nil {
+		return p.tokenError(err)
+	}
+
+	node.Get(prefix).Range = p.tokenRange(start)
+
+	return nil
+}
+
+// parseEOF checks that nothing but whitespace follows the top-level value.
+func (p *jsonParser) parseEOF() error {
+	start := p.tokenStart()
+	if start >= len(p.data) {
+		return nil
+	}
+
+	pos := p.lines.position(start)
+
+	return fmt.Errorf("line %d, column %d: %w", pos.Line, pos.Column, errJSONTrailingData)
+}
+
+// tokenError reports an unexpected end of the document as
+// io.ErrUnexpectedEOF rather than io.EOF, which callers treat as success.
+func (p *jsonParser) tokenError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected end of JSON input", io.ErrUnexpectedEOF)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// resolveJSONScalar converts a JSON scalar token into a Go value. Integers
+// become int64 (uint64 if they do not fit), other numbers float64, matching
+// the values produced by YamlFormat.
+func resolveJSONScalar(token json.Token) any {
+	number, ok := token.(json.Number)
+	if !ok {
+		return token
+	}
+
+	i, err := strconv.ParseInt(number.String(), 10, 64)
+	if err == nil {
+		return i
+	}
+
+	u, err := strconv.ParseUint(number.String(), 10, 64)
+	if err == nil {
+		return u
+	}
+
+	f, err := number.Float64()
+	if err == nil {
+		return f
+	}
+
+	return number.String()
+}
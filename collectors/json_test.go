@@ -0,0 +1,240 @@
+package collectors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-config/tree"
+)
+
+const configJSON = `{
+  "storage": {"provider": "etcd", "timeout": 3.5},
+  "replication": {
+    "failover": "manual",
+    "peers": ["a", "b"],
+    "enabled": true,
+    "bootstrap": null
+  },
+  "memory": 1024,
+  "big": 18446744073709551615
+}
+`
+
+func TestNewJSONFormat(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewJSONFormat()
+	require.NotNil(t, format)
+
+	assert.Equal(t, "json", format.Name())
+	assert.True(t, format.KeepOrder())
+}
+
+func TestJSON_Parse(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewJSONFormat().From(strings.NewReader(configJSON)).Parse()
+	require.NoError(t, err)
+	require.NotNil(t, root)
+
+	assert.Equal(t, []string{"storage", "replication", "memory", "big"}, root.ChildrenKeys())
+	assert.Equal(t, []string{"failover", "peers", "enabled", "bootstrap"},
+		root.Get(config.NewKeyPath("replication")).ChildrenKeys())
+
+	assert.Equal(t, "etcd", root.GetValue(config.NewKeyPath("storage/provider")))
+	assert.InDelta(t, 3.5, root.GetValue(config.NewKeyPath("storage/timeout")), 0)
+	assert.Equal(t, true, root.GetValue(config.NewKeyPath("replication/enabled")))
+	assert.Nil(t, root.GetValue(config.NewKeyPath("replication/bootstrap")))
+	assert.Equal(t, int64(1024), root.GetValue(config.NewKeyPath("memory")))
+	assert.Equal(t, uint64(18446744073709551615), root.GetValue(config.NewKeyPath("big")))
+
+	peers := root.Get(config.NewKeyPath("replication/peers"))
+	require.NotNil(t, peers)
+	assert.True(t, peers.IsArray())
+	assert.Equal(t, "b", root.GetValue(config.NewKeyPath("replication/peers/1")))
+}
+
+func TestJSON_Parse_Ranges(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewJSONFormat().From(strings.NewReader(configJSON)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, tree.NewRange(2, 27, 2, 32), root.Get(config.NewKeyPath("storage/provider")).Range)
+	assert.Equal(t, tree.NewRange(2, 46, 2, 48), root.Get(config.NewKeyPath("storage/timeout")).Range)
+	assert.Equal(t, tree.NewRange(5, 15, 5, 17), root.Get(config.NewKeyPath("replication/peers/0")).Range)
+	assert.Equal(t, tree.NewRange(5, 14, 5, 23), root.Get(config.NewKeyPath("replication/peers")).Range)
+	assert.Equal(t, tree.NewRange(9, 13, 9, 16), root.Get(config.NewKeyPath("memory")).Range)
+	assert.Equal(t, tree.NewRange(3, 18, 8, 3), root.Get(config.NewKeyPath("replication")).Range)
+}
+
+func TestJSON_Parse_EmptyContainers(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewJSONFormat().From(strings.NewReader(`{"a": {}, "b": [], "c": {"d": {}}}`)).Parse()
+	require.NoError(t, err)
+
+	node := root.Get(config.NewKeyPath("a"))
+	require.NotNil(t, node)
+	assert.Equal(t, map[string]any{}, node.Value)
+
+	node = root.Get(config.NewKeyPath("b"))
+	require.NotNil(t, node)
+	assert.True(t, node.IsArray())
+	assert.Empty(t, node.Children())
+
+	node = root.Get(config.NewKeyPath("c/d"))
+	require.NotNil(t, node)
+	assert.Equal(t, map[string]any{}, node.Value)
+}
+
+func TestJSON_Parse_DuplicateKey(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewJSONFormat().From(strings.NewReader(`{"a": {"x": 1}, "b": 2, "a": 3}`)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), root.GetValue(config.NewKeyPath("a")))
+	assert.Nil(t, root.Get(config.NewKeyPath("a/x")))
+}
+
+func TestJSON_Parse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewJSONFormat().From(nil).Parse()
+	require.Nil(t, root)
+	require.ErrorIs(t, err, collectors.ErrNoData)
+
+	for data, location := range map[string]string{
+		"{\n  \"a\": 1,\n  \"b\" 2\n}": "line 3, column",
+		"{\"a\": [1, 2}":               "line 1, column",
+		"{\"a\": 1":                    "unexpected end",
+		"{\"a\": 1}\n{\"b\": 2}":       "line 2, column 1",
+	} {
+		root, err = collectors.NewJSONFormat().From(strings.NewReader(data)).Parse()
+		require.Nil(t, root, data)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, data)
+		assert.Contains(t, err.Error(), location, data)
+	}
+}
+
+func TestJSON_Source_Builder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(configJSON), 0o600))
+
+	collector, err := collectors.NewSource(context.Background(), collectors.NewFile(path),
+		collectors.NewJSONFormat())
+	require.NoError(t, err)
+	assert.True(t, collector.KeepOrder())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	var peers []string
+
+	_, err = cfg.Get(config.NewKeyPath("replication/peers"), &peers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, peers)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("memory"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 1)
+	assert.Equal(t, tree.NewRange(9, 13, 9, 16), explanation.Layers[0].Range)
+}
+
+func TestJSON_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "app.json", `{"port": 8080}`)
+	writeTestFile(t, dir, "db.json", `{"dbhost": "postgres", "replicas": ["r1"]}`)
+	writeTestFile(t, dir, "ignored.yaml", "port: 1")
+
+	subs, err := collectors.NewDirectory(dir, ".json", collectors.NewJSONFormat()).Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewDirectory(dir, ".json", collectors.NewJSONFormat()))
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, int64(8080), config.MustGet[int64](&cfg, config.NewKeyPath("port")))
+	assert.Equal(t, []string{"r1"}, config.MustGet[[]string](&cfg, config.NewKeyPath("replicas")))
+}
+
+func TestJSON_Directory_ParseError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "broken.json", `{"port": }`)
+
+	_, err := collectors.NewDirectory(dir, ".json", collectors.NewJSONFormat()).Collectors(context.Background())
+
+	var parseErr *collectors.FormatParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, filepath.Join(dir, "broken.json"), parseErr.Key)
+	assert.ErrorIs(t, err, collectors.ErrUnmarshall)
+}
+
+func TestJSON_Storage(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "cfg-instances",
+		[]byte(`{"instances": {"i001": {"roles": ["router"], "memory": "1G"}}}`))
+
+	typed := testutil.NewRawTyped(mock, "/config/")
+	channel := collectors.NewStorage(typed, "/config/", collectors.NewJSONFormat()).Read(context.Background())
+
+	valuesMap := make(map[string]any)
+
+	for val := range channel {
+		var dest any
+
+		require.NoError(t, val.Get(&dest))
+
+		valuesMap[val.Meta().Key.String()] = dest
+	}
+
+	assert.Equal(t, map[string]any{
+		"instances/i001/roles/0": "router",
+		"instances/i001/memory":  "1G",
+	}, valuesMap)
+}
+
+func TestJSON_Storage_ParseError(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "cfg-broken", []byte(`{"replication":`))
+	testutil.PutIntegrity(mock, "/config/", "cfg-valid", []byte(`{"mykey": "value"}`))
+
+	typed := testutil.NewRawTyped(mock, "/config/")
+	collector := collectors.NewStorage(typed, "/config/", collectors.NewJSONFormat())
+
+	_, err := collector.Collectors(context.Background())
+
+	var parseErr *collectors.FormatParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "/config/cfg-broken", parseErr.Key)
+
+	subs, err := collector.WithSkipInvalid(true).Collectors(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+}
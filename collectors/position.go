@@ -0,0 +1,45 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tarantool/go-config/tree"
+)
+
+// lineIndex maps byte offsets of a document to line and column positions.
+// It holds the offset at which every line starts.
+type lineIndex []int
+
+func newLineIndex(data []byte) lineIndex {
+	index := lineIndex{0}
+
+	for i, char := range data {
+		if char == '\n' {
+			index = append(index, i+1)
+		}
+	}
+
+	return index
+}
+
+// position converts a byte offset into a 1-based line and column.
+func (index lineIndex) position(offset int) tree.Position {
+	line := sort.Search(len(index), func(i int) bool {
+		return index[i] > offset
+	})
+
+	return tree.Position{Line: line, Column: offset - index[line-1] + 1}
+}
+
+// span returns the range between the bytes at offsets start and end.
+func (index lineIndex) span(start, end int) tree.Range {
+	return tree.Range{Start: index.position(start), End: index.position(max(end, start))}
+}
+
+// errorf builds a parse error wrapping sentinel and located at offset.
+func (index lineIndex) errorf(sentinel error, offset int, format string, args ...any) error {
+	pos := index.position(offset)
+
+	return fmt.Errorf("%w: line %d, column %d: %s", sentinel, pos.Line, pos.Column, fmt.Sprintf(format, args...))
+}
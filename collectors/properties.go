@@ -0,0 +1,305 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// PropertiesFormat implements Format interface for Java-style .properties
+// files.
+//
+// Every line holds a "key=value", "key: value" or "key value" entry; lines
+// starting with '#' or '!' are comments and a backslash at the end of a line
+// continues the entry on the next one. Keys and values may use the \t, \n,
+// \r, \f and \uXXXX escapes, and a backslash escapes any other character
+// (e.g. "\=" or "\ "). Keys are split by the delimiter ("." by default), so
+// server.port=8080 becomes server/port; their case is kept. All values are
+// strings.
+type PropertiesFormat struct {
+	name      string
+	keepOrder bool
+	data      []byte
+	reader    io.Reader
+	keys      flatKeys
+}
+
+// PropertiesOption configures a PropertiesFormat.
+type PropertiesOption func(*PropertiesFormat)
+
+// WithPropertiesPrefix sets a prefix to strip from keys. If set, only keys
+// starting with this prefix are processed.
+func WithPropertiesPrefix(prefix string) PropertiesOption {
+	return func(f *PropertiesFormat) {
+		f.keys.prefix = prefix
+	}
+}
+
+// WithPropertiesDelimiter sets the delimiter used to split keys into key path
+// segments. The default is dot ('.').
+func WithPropertiesDelimiter(delim string) PropertiesOption {
+	return func(f *PropertiesFormat) {
+		f.keys.delimiter = delim
+	}
+}
+
+// WithPropertiesTransform sets a custom transformation from a key (with the
+// prefix stripped) to a KeyPath. The delimiter is ignored.
+func WithPropertiesTransform(fn func(string) config.KeyPath) PropertiesOption {
+	if fn == nil {
+		panic("transform function cannot be nil")
+	}
+
+	return func(f *PropertiesFormat) {
+		f.keys.transform = fn
+	}
+}
+
+// NewPropertiesFormat return new PropertiesFormat object.
+func NewPropertiesFormat(opts ...PropertiesOption) Format {
+	format := PropertiesFormat{
+		name:      "properties",
+		keepOrder: true,
+		data:      nil,
+		reader:    nil,
+		keys: flatKeys{
+			prefix:    "",
+			delimiter: ".",
+			lowercase: false,
+			transform: nil,
+		},
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&format)
+		}
+	}
+
+	return format
+}
+
+// Name implements the Format interface.
+func (f PropertiesFormat) Name() string {
+	return f.name
+}
+
+// KeepOrder implements the Format interface.
+func (f PropertiesFormat) KeepOrder() bool {
+	return f.keepOrder
+}
+
+// From implements the Format interface.
+func (f PropertiesFormat) From(reader io.Reader) Format {
+	f.reader = reader
+	return f
+}
+
+// Parse implements the Format interface.
+func (f PropertiesFormat) Parse() (*tree.Node, error) {
+	if f.reader != nil {
+		dataFromReader, err := io.ReadAll(f.reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReader, err)
+		}
+
+		f.data = append(f.data, dataFromReader...)
+	}
+
+	if f.data == nil {
+		return nil, ErrNoData
+	}
+
+	parser := &propertiesParser{
+		data:  f.data,
+		pos:   0,
+		lines: newLineIndex(f.data),
+	}
+
+	root := tree.New()
+
+	err := parser.parse(func(key, value string, rng tree.Range) {
+		f.keys.set(root, key, value, rng)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
+	}
+
+	return root, nil
+}
+
+// errPropertiesSyntax is wrapped by every error of the properties parser.
+var errPropertiesSyntax = errors.New("invalid properties")
+
+// propertiesParser parses a .properties file.
+type propertiesParser struct {
+	data  []byte
+	pos   int
+	lines lineIndex
+}
+
+func (p *propertiesParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *propertiesParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+func isPropertiesSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\f'
+}
+
+func (p *propertiesParser) skipSpace() {
+	for isPropertiesSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// skipNewline consumes a single "\n", "\r\n" or "\r" and reports whether it
+// did.
+func (p *propertiesParser) skipNewline() bool {
+	switch p.peek() {
+	case '\n':
+		p.pos++
+	case '\r':
+		p.pos++
+
+		if p.peek() == '\n' {
+			p.pos++
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// parse calls set for every entry, in file order.
+func (p *propertiesParser) parse(set func(key, value string, rng tree.Range)) error {
+	for !p.eof() {
+		p.skipSpace()
+
+		if p.skipNewline() {
+			continue
+		}
+
+		if p.peek() == '#' || p.peek() == '!' {
+			for !p.eof() && p.peek() != '\n' && p.peek() != '\r' {
+				p.pos++
+			}
+
+			continue
+		}
+
+		key, err := p.readPart(true)
+		if err != nil {
+			return err
+		}
+
+		p.skipSpace()
+
+		if p.peek() == '=' || p.peek() == ':' {
+			p.pos++
+			p.skipSpace()
+		}
+
+		start := p.pos
+
+		value, err := p.readPart(false)
+		if err != nil {
+			return err
+		}
+
+		set(key, value, p.lines.span(start, p.pos-1))
+	}
+
+	return nil
+}
+
+// readPart reads a key, up to an unescaped separator or whitespace, or a
+// value, up to the end of the logical line.
+func (p *propertiesParser) readPart(key bool) (string, error) {
+	var builder strings.Builder
+
+	for !p.eof() {
+		char := p.data[p.pos]
+
+		switch {
+		case char == '\n' || char == '\r':
+			return builder.String(), nil
+		case key && (char == '=' || char == ':' || isPropertiesSpace(char)):
+			return builder.String(), nil
+		case char != '\\':
+			builder.WriteByte(char)
+			p.pos++
+		default:
+			err := p.readEscape(&builder)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// readEscape reads an escape sequence or a line continuation.
+func (p *propertiesParser) readEscape(builder *strings.Builder) error {
+	start := p.pos
+	p.pos++
+
+	if p.eof() {
+		return nil
+	}
+
+	if p.skipNewline() {
+		// A line continuation: leading whitespace of the next line is
+		// dropped.
+		p.skipSpace()
+
+		return nil
+	}
+
+	escape := p.data[p.pos]
+	p.pos++
+
+	switch escape {
+	case 't':
+		builder.WriteByte('\t')
+	case 'n':
+		builder.WriteByte('\n')
+	case 'r':
+		builder.WriteByte('\r')
+	case 'f':
+		builder.WriteByte('\f')
+	case 'u':
+		const size = 4
+
+		if p.pos+size > len(p.data) {
+			return p.lines.errorf(errPropertiesSyntax, start, "invalid unicode escape")
+		}
+
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.lines.errorf(errPropertiesSyntax, start, "invalid unicode escape")
+		}
+
+		builder.WriteRune(rune(code))
+		p.pos += size
+	default:
+		builder.WriteByte(escape)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package collectors_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/tree"
+)
+
+const configProperties = `# Sidecar settings.
+! Another comment.
+server.host = localhost
+server.port: 8080
+server.name   Main Server
+app.greeting=Hello, \
+             World
+app.path=C:\\Apps\\sidecar
+key\ with\ spaces=value\=with\:separators
+unicode=caf\u00e9
+app.tabs=a\tb
+empty.value=
+`
+
+func TestNewPropertiesFormat(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewPropertiesFormat()
+
+	assert.Equal(t, "properties", format.Name())
+	assert.True(t, format.KeepOrder())
+}
+
+func TestProperties_Parse(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewPropertiesFormat().From(strings.NewReader(configProperties)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"server", "app", "key with spaces", "unicode", "empty"}, root.ChildrenKeys())
+	assert.Equal(t, "localhost", root.GetValue(config.NewKeyPath("server/host")))
+	assert.Equal(t, "8080", root.GetValue(config.NewKeyPath("server/port")))
+	assert.Equal(t, "Main Server", root.GetValue(config.NewKeyPath("server/name")))
+	assert.Equal(t, "Hello, World", root.GetValue(config.NewKeyPath("app/greeting")))
+	assert.Equal(t, `C:\Apps\sidecar`, root.GetValue(config.NewKeyPath("app/path")))
+	assert.Equal(t, "value=with:separators", root.GetValue(config.NewKeyPath("key with spaces")))
+	assert.Equal(t, "café", root.GetValue(config.NewKeyPath("unicode")))
+	assert.Equal(t, "a\tb", root.GetValue(config.NewKeyPath("app/tabs")))
+	assert.Empty(t, root.GetValue(config.NewKeyPath("empty/value")))
+
+	assert.Equal(t, tree.NewRange(4, 14, 4, 17), root.Get(config.NewKeyPath("server/port")).Range)
+	assert.Equal(t, tree.NewRange(6, 14, 7, 18), root.Get(config.NewKeyPath("app/greeting")).Range)
+}
+
+func TestProperties_Parse_Delimiter(t *testing.T) {
+	t.Parallel()
+
+	data := "myapp/db/host=h\nmyapp/name=n\nother/key=x\n"
+
+	root, err := collectors.NewPropertiesFormat(
+		collectors.WithPropertiesPrefix("myapp/"), collectors.WithPropertiesDelimiter("/")).
+		From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"db", "name"}, root.ChildrenKeys())
+	assert.Equal(t, "h", root.GetValue(config.NewKeyPath("db/host")))
+
+	root, err = collectors.NewPropertiesFormat(
+		collectors.WithPropertiesTransform(func(key string) config.KeyPath {
+			return config.NewKeyPathFromSegments([]string{strings.ToUpper(key)})
+		})).
+		From(strings.NewReader("a.b=1\n")).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", root.Child("A.B").Value)
+}
+
+func TestProperties_Parse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewPropertiesFormat().From(nil).Parse()
+	require.Nil(t, root)
+	require.ErrorIs(t, err, collectors.ErrNoData)
+
+	root, err = collectors.NewPropertiesFormat().From(strings.NewReader("a=1\nb=\\u12")).Parse()
+	require.Nil(t, root)
+	require.ErrorIs(t, err, collectors.ErrUnmarshall)
+	assert.Contains(t, err.Error(), "line 2, column 3: invalid unicode escape")
+}
+
+func TestProperties_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "app.properties", "server.port=9000\n")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewDirectory(dir, ".properties", collectors.NewPropertiesFormat()))
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 9000, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+}
@@ -0,0 +1,310 @@
+package collectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/tarantool/go-config/tree"
+)
+
+// FormatSpec describes how a [Format] is recognized by a [FormatRegistry].
+type FormatSpec struct {
+	// Format is the format used to parse matching data.
+	Format Format
+	// Extensions lists the file extensions of the format, including the
+	// leading dot (e.g., ".yaml"). Matching is case-insensitive.
+	Extensions []string
+	// MIMETypes lists the media types of the format (e.g., "application/json").
+	// Parameters such as charset are ignored when matching.
+	MIMETypes []string
+	// Sniff reports whether data looks like a document of the format. It may
+	// be nil: the format is then never detected from the content alone.
+	Sniff func(data []byte) bool
+}
+
+// FormatRegistry maps file extensions and MIME types onto formats and detects
+// the format of raw data by its content. Formats registered later take
+// precedence, so a registry built with [DefaultFormatRegistry] can be
+// extended or overridden.
+type FormatRegistry struct {
+	specs []FormatSpec
+}
+
+// NewFormatRegistry returns an empty FormatRegistry.
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{specs: nil}
+}
+
+// DefaultFormatRegistry returns a new FormatRegistry holding the formats
+// of this package:
+//   - YAML: ".yaml", ".yml"; "application/yaml", "application/x-yaml",
+//     "text/yaml";
+//   - JSON: ".json"; "application/json";
+//   - TOML: ".toml"; "application/toml";
+//   - dotenv: ".env";
+//   - properties: ".properties"; "text/x-java-properties".
+//
+// JSON, TOML and YAML (in that order) are detected by content; dotenv and
+// properties files are too ambiguous to sniff and need an extension.
+func DefaultFormatRegistry() *FormatRegistry {
+	return NewFormatRegistry().
+		Register(FormatSpec{
+			Format:     NewPropertiesFormat(),
+			Extensions: []string{".properties"},
+			MIMETypes:  []string{"text/x-java-properties"},
+			Sniff:      nil,
+		}).
+		Register(FormatSpec{
+			Format:     NewDotenvFormat(),
+			Extensions: []string{".env"},
+			MIMETypes:  nil,
+			Sniff:      nil,
+		}).
+		Register(FormatSpec{
+			Format:     NewYamlFormat(),
+			Extensions: []string{".yaml", ".yml"},
+			MIMETypes:  []string{"application/yaml", "application/x-yaml", "text/yaml"},
+			Sniff:      sniffYAML,
+		}).
+		Register(FormatSpec{
+			Format:     NewTOMLFormat(),
+			Extensions: []string{".toml"},
+			MIMETypes:  []string{"application/toml"},
+			Sniff:      sniffTOML,
+		}).
+		Register(FormatSpec{
+			Format:     NewJSONFormat(),
+			Extensions: []string{".json"},
+			MIMETypes:  []string{"application/json"},
+			Sniff:      sniffJSON,
+		})
+}
+
+// Register adds a format to the registry. It takes precedence over formats
+// registered before with the same extension or MIME type, and is sniffed
+// before them.
+func (r *FormatRegistry) Register(spec FormatSpec) *FormatRegistry {
+	r.specs = append(r.specs, spec)
+	return r
+}
+
+// Extensions returns all registered extensions, most recently registered
+// first, without duplicates.
+func (r *FormatRegistry) Extensions() []string {
+	var extensions []string
+
+	seen := make(map[string]bool)
+
+	for i := len(r.specs) - 1; i >= 0; i-- {
+		for _, ext := range r.specs[i].Extensions {
+			ext = strings.ToLower(ext)
+			if !seen[ext] {
+				seen[ext] = true
+				extensions = append(extensions, ext)
+			}
+		}
+	}
+
+	return extensions
+}
+
+// ByExtension returns the format registered for the extension of the given
+// file name or path. A bare extension (e.g., ".json") is accepted too.
+func (r *FormatRegistry) ByExtension(name string) (Format, bool) {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" {
+		return nil, false
+	}
+
+	for i := len(r.specs) - 1; i >= 0; i-- {
+		for _, candidate := range r.specs[i].Extensions {
+			if strings.ToLower(candidate) == ext {
+				return r.specs[i].Format, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// ByMIMEType returns the format registered for the media type of the given
+// Content-Type value (e.g., "application/json; charset=utf-8").
+func (r *FormatRegistry) ByMIMEType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	for i := len(r.specs) - 1; i >= 0; i-- {
+		for _, candidate := range r.specs[i].MIMETypes {
+			if strings.EqualFold(candidate, mediaType) {
+				return r.specs[i].Format, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Sniff returns the first format, most recently registered first, whose
+// Sniff function accepts data.
+func (r *FormatRegistry) Sniff(data []byte) (Format, bool) {
+	for i := len(r.specs) - 1; i >= 0; i-- {
+		if r.specs[i].Sniff != nil && r.specs[i].Sniff(data) {
+			return r.specs[i].Format, true
+		}
+	}
+
+	return nil, false
+}
+
+// Detect returns the format for a document: by the extension of name, then
+// by the media type of contentType, then by sniffing data. Empty name or
+// contentType are skipped. Returns ErrUnknownFormat if nothing matches.
+func (r *FormatRegistry) Detect(name, contentType string, data []byte) (Format, error) {
+	if name != "" {
+		if format, ok := r.ByExtension(name); ok {
+			return format, nil
+		}
+	}
+
+	if contentType != "" {
+		if format, ok := r.ByMIMEType(contentType); ok {
+			return format, nil
+		}
+	}
+
+	if format, ok := r.Sniff(data); ok {
+		return format, nil
+	}
+
+	if name != "" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// sniffJSON accepts a valid JSON object or array.
+func sniffJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)
+}
+
+// sniffTOML accepts a non-empty valid TOML document.
+func sniffTOML(data []byte) bool {
+	return sniffParse(NewTOMLFormat(), data)
+}
+
+// sniffYAML accepts a YAML mapping or sequence; a plain scalar is not
+// considered a configuration document.
+func sniffYAML(data []byte) bool {
+	return sniffParse(NewYamlFormat(), data)
+}
+
+func sniffParse(format Format, data []byte) bool {
+	root, err := format.From(bytes.NewReader(data)).Parse()
+
+	return err == nil && root != nil && len(root.Children()) > 0
+}
+
+// AutoFormat implements Format interface by detecting the actual format of
+// the data with a [FormatRegistry].
+//
+// The format is chosen by the file extension, then by the MIME type, then by
+// the content. The file name is taken from WithFileName or, when unset, from
+// a reader with a Name method (such as *os.File, which [File] returns); the
+// MIME type is taken from WithMIMEType or from a reader with a ContentType
+// method.
+type AutoFormat struct {
+	name     string
+	registry *FormatRegistry
+	fileName string
+	mimeType string
+	data     []byte
+	reader   io.Reader
+}
+
+// NewAutoFormat returns new AutoFormat object detecting formats of the given
+// registry. A nil registry means [DefaultFormatRegistry].
+func NewAutoFormat(registry *FormatRegistry) AutoFormat {
+	if registry == nil {
+		registry = DefaultFormatRegistry()
+	}
+
+	return AutoFormat{
+		name:     "auto",
+		registry: registry,
+		fileName: "",
+		mimeType: "",
+		data:     nil,
+		reader:   nil,
+	}
+}
+
+// WithFileName sets the file name used to detect the format by extension.
+func (a AutoFormat) WithFileName(name string) AutoFormat {
+	a.fileName = name
+	return a
+}
+
+// WithMIMEType sets the media type used to detect the format.
+func (a AutoFormat) WithMIMEType(contentType string) AutoFormat {
+	a.mimeType = contentType
+	return a
+}
+
+// Name implements the Format interface.
+func (a AutoFormat) Name() string {
+	return a.name
+}
+
+// KeepOrder implements the Format interface.
+func (a AutoFormat) KeepOrder() bool {
+	return true
+}
+
+// From implements the Format interface.
+func (a AutoFormat) From(reader io.Reader) Format {
+	a.reader = reader
+	return a
+}
+
+// Parse implements the Format interface.
+func (a AutoFormat) Parse() (*tree.Node, error) {
+	fileName, mimeType := a.fileName, a.mimeType
+
+	if a.reader != nil {
+		if fileName == "" {
+			fileName = readerName(a.reader)
+		}
+
+		if typed, ok := a.reader.(interface{ ContentType() string }); ok && mimeType == "" {
+			mimeType = typed.ContentType()
+		}
+
+		dataFromReader, err := io.ReadAll(a.reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReader, err)
+		}
+
+		a.data = append(a.data, dataFromReader...)
+	}
+
+	if a.data == nil {
+		return nil, ErrNoData
+	}
+
+	format, err := a.registry.Detect(fileName, mimeType, a.data)
+	if err != nil {
+		return nil, err
+	}
+
+	return format.From(bytes.NewReader(a.data)).Parse() //nolint:wrapcheck
+}
@@ -0,0 +1,277 @@
+package collectors_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+func TestFormatRegistry_ByExtension(t *testing.T) {
+	t.Parallel()
+
+	registry := collectors.DefaultFormatRegistry()
+
+	for name, expected := range map[string]string{
+		"a.yaml":                "yaml",
+		"/etc/app/b.YML":        "yaml",
+		"c.json":                "json",
+		".json":                 "json",
+		"conf.d/d.toml":         "toml",
+		".env":                  "dotenv",
+		"app.properties":        "properties",
+		"archive.tar.json.yaml": "yaml",
+	} {
+		format, ok := registry.ByExtension(name)
+		require.True(t, ok, name)
+		assert.Equal(t, expected, format.Name(), name)
+	}
+
+	for _, name := range []string{"README", "notes.txt", ""} {
+		_, ok := registry.ByExtension(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestFormatRegistry_ByMIMEType(t *testing.T) {
+	t.Parallel()
+
+	registry := collectors.DefaultFormatRegistry()
+
+	format, ok := registry.ByMIMEType("application/json; charset=utf-8")
+	require.True(t, ok)
+	assert.Equal(t, "json", format.Name())
+
+	format, ok = registry.ByMIMEType("Application/X-YAML")
+	require.True(t, ok)
+	assert.Equal(t, "yaml", format.Name())
+
+	_, ok = registry.ByMIMEType("text/html")
+	assert.False(t, ok)
+
+	_, ok = registry.ByMIMEType("not a media type;")
+	assert.False(t, ok)
+}
+
+func TestFormatRegistry_Sniff(t *testing.T) {
+	t.Parallel()
+
+	registry := collectors.DefaultFormatRegistry()
+
+	for data, expected := range map[string]string{
+		`{"server": {"port": 8080}}`:      "json",
+		"  [1, 2, 3]\n":                   "json",
+		"[server]\nport = 8080\n":         "toml",
+		"title = \"app\"\n":               "toml",
+		"server:\n  port: 8080\n":         "yaml",
+		"- a\n- b\n":                      "yaml",
+		"{server: {port: 8080}}":          "yaml",
+		"# comment\nserver:\n  port: 1\n": "yaml",
+	} {
+		format, ok := registry.Sniff([]byte(data))
+		require.True(t, ok, data)
+		assert.Equal(t, expected, format.Name(), data)
+	}
+
+	for _, data := range []string{"", "just a scalar", "HOST=local host\n"} {
+		_, ok := registry.Sniff([]byte(data))
+		assert.False(t, ok, data)
+	}
+}
+
+func TestFormatRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := collectors.DefaultFormatRegistry().Register(collectors.FormatSpec{
+		Format:     collectors.NewDotenvFormat(collectors.WithDotenvPrefix("APP_")),
+		Extensions: []string{".conf", ".YAML"},
+		MIMETypes:  []string{"text/plain"},
+		Sniff: func(data []byte) bool {
+			return strings.HasPrefix(string(data), "APP_")
+		},
+	})
+
+	format, ok := registry.ByExtension("x.yaml")
+	require.True(t, ok)
+	assert.Equal(t, "dotenv", format.Name())
+
+	format, ok = registry.ByMIMEType("text/plain")
+	require.True(t, ok)
+	assert.Equal(t, "dotenv", format.Name())
+
+	format, ok = registry.Sniff([]byte("APP_A=1\n"))
+	require.True(t, ok)
+	assert.Equal(t, "dotenv", format.Name())
+
+	assert.Equal(t,
+		[]string{".conf", ".yaml", ".json", ".toml", ".yml", ".env", ".properties"},
+		registry.Extensions())
+
+	assert.Empty(t, collectors.NewFormatRegistry().Extensions())
+}
+
+func TestFormatRegistry_Detect(t *testing.T) {
+	t.Parallel()
+
+	registry := collectors.DefaultFormatRegistry()
+
+	format, err := registry.Detect("a.toml", "application/json", []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "toml", format.Name())
+
+	format, err = registry.Detect("a.txt", "application/json", []byte(`a: 1`))
+	require.NoError(t, err)
+	assert.Equal(t, "json", format.Name())
+
+	format, err = registry.Detect("", "", []byte("a: 1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", format.Name())
+
+	_, err = registry.Detect("a.txt", "", []byte("plain text"))
+	require.ErrorIs(t, err, collectors.ErrUnknownFormat)
+	assert.Contains(t, err.Error(), `"a.txt"`)
+
+	_, err = registry.Detect("", "", nil)
+	require.ErrorIs(t, err, collectors.ErrUnknownFormat)
+}
+
+func TestAutoFormat_Parse(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewAutoFormat(nil)
+	assert.Equal(t, "auto", format.Name())
+	assert.True(t, format.KeepOrder())
+
+	root, err := format.From(strings.NewReader("[server]\nport = 8080\n")).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, int64(8080), root.GetValue(config.NewKeyPath("server/port")))
+
+	// The file name wins over the content.
+	root, err = format.WithFileName(".env").From(strings.NewReader("SERVER_PORT=8080\n")).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, "8080", root.GetValue(config.NewKeyPath("server/port")))
+
+	root, err = format.WithMIMEType("text/x-java-properties").
+		From(strings.NewReader("server.port=8080\n")).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, "8080", root.GetValue(config.NewKeyPath("server/port")))
+
+	_, err = format.From(strings.NewReader("plain text")).Parse()
+	require.ErrorIs(t, err, collectors.ErrUnknownFormat)
+
+	_, err = format.Parse()
+	require.ErrorIs(t, err, collectors.ErrNoData)
+}
+
+func TestAutoFormat_Source(t *testing.T) {
+	t.Parallel()
+
+	// The extension of the opened file selects the format: the content is
+	// valid YAML as well, but it is parsed as JSON, keeping the number type.
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"server": {"port": 8080}}`), 0o600))
+
+	collector, err := collectors.NewSource(context.Background(), collectors.NewFile(path),
+		collectors.NewAutoFormat(nil))
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+}
+
+func TestDirectory_WithFormats(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml", "server:\n  host: localhost\n")
+	writeTestFile(t, dir, "b.yml", "server:\n  port: 8080\n")
+	writeTestFile(t, dir, "c.json", `{"database": {"driver": "postgres"}}`)
+	writeTestFile(t, dir, "README.md", "# not a config\n")
+
+	collector := collectors.NewDirectory(dir, "", nil).
+		WithFormats(collectors.DefaultFormatRegistry())
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, "localhost", config.MustGet[string](&cfg, config.NewKeyPath("server/host")))
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "postgres", config.MustGet[string](&cfg, config.NewKeyPath("database/driver")))
+}
+
+func TestDirectory_WithFormats_Sniff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.conf", "[server]\nport = 8080\n")
+	writeTestFile(t, dir, "b.conf", "database:\n  driver: postgres\n")
+
+	collector := collectors.NewDirectory(dir, ".conf", nil).
+		WithFormats(collectors.DefaultFormatRegistry())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "postgres", config.MustGet[string](&cfg, config.NewKeyPath("database/driver")))
+
+	writeTestFile(t, dir, "c.conf", "plain text\n")
+
+	_, err := collector.Collectors(context.Background())
+	require.ErrorIs(t, err, collectors.ErrUnknownFormat)
+
+	var parseErr *collectors.FormatParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, filepath.Join(dir, "c.conf"), parseErr.Key)
+}
+
+func TestDirectory_WithFormats_Fallback(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.json", `{"a": 1}`)
+	writeTestFile(t, dir, "b.cfg", "b: 2\n")
+
+	// Files with an unregistered extension are parsed with the collector's
+	// format.
+	collector := collectors.NewDirectory(dir, "", collectors.NewYamlFormat()).
+		WithFormats(collectors.DefaultFormatRegistry())
+
+	subs, err := collector.Collectors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	collector = collectors.NewDirectory(dir, ".cfg", collectors.NewYamlFormat()).
+		WithFormats(collectors.DefaultFormatRegistry())
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 2, config.MustGet[int](&cfg, config.NewKeyPath("b")))
+}
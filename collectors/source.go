@@ -1,11 +1,15 @@
 package collectors
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/tree"
@@ -20,11 +24,27 @@ type DataSource interface {
 }
 
 // File implements DataSource with data from file.
+//
+// The revision of a File is the SHA-256 hash of the content it read last, and
+// File implements [Watcher] by polling the file, so a collector created with
+// [NewSource] is reloaded by a [config.Reloader] when the content changes. The
+// file is reopened by path on every check, so replacing it by an atomic rename
+// (as editors and Kubernetes ConfigMap volumes do) is detected too.
 type File struct {
 	name       string
 	sourceType config.SourceType
 	revision   config.RevisionType
+	fsys       fs.FS
 	file       string
+	interval   time.Duration
+	debounce   time.Duration
+	state      *fileState
+}
+
+// fileState is the state shared by the copies of a File.
+type fileState struct {
+	mu       sync.RWMutex
+	revision config.RevisionType
 }
 
 // NewFile returns new File object.
@@ -33,8 +53,44 @@ func NewFile(file string) File {
 		name:       "file",
 		sourceType: config.FileSource,
 		revision:   "",
+		fsys:       nil,
 		file:       file,
+		interval:   DefaultFilePollInterval,
+		debounce:   DefaultFileDebounce,
+		state:      &fileState{mu: sync.RWMutex{}, revision: ""},
+	}
+}
+
+// NewFileFS returns new File object reading the file from fsys (such as
+// embed.FS) instead of the local filesystem. The file name follows the fs.FS
+// naming rules: it is slash-separated and unrooted.
+func NewFileFS(fsys fs.FS, file string) File {
+	f := NewFile(file)
+	f.fsys = fsys
+
+	return f
+}
+
+// WithPollInterval returns a copy of the File checking the file for changes
+// at the given interval (default [DefaultFilePollInterval]). A value <= 0
+// restores the default.
+func (f File) WithPollInterval(interval time.Duration) File {
+	if interval <= 0 {
+		interval = DefaultFilePollInterval
 	}
+
+	f.interval = interval
+
+	return f
+}
+
+// WithDebounce returns a copy of the File reporting a change only after the
+// new content stayed the same for the given duration (default
+// [DefaultFileDebounce]). A value <= 0 reports changes as soon as they are
+// seen.
+func (f File) WithDebounce(debounce time.Duration) File {
+	f.debounce = max(debounce, 0)
+	return f
 }
 
 // Name returns name of the source.
@@ -47,19 +103,98 @@ func (f File) SourceType() config.SourceType {
 	return f.sourceType
 }
 
-// Revision returns data revision.
+// Revision returns data revision: the SHA-256 hash of the content read by the
+// last FetchStream, or an empty string before the first one.
 func (f File) Revision() config.RevisionType {
-	return f.revision
+	if f.revision != "" || f.state == nil {
+		return f.revision
+	}
+
+	f.state.mu.RLock()
+	defer f.state.mu.RUnlock()
+
+	return f.state.revision
 }
 
 // FetchStream returns reader.
 func (f File) FetchStream(_ context.Context) (io.ReadCloser, error) {
-	reader, err := os.Open(filepath.Clean(f.file))
+	data, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.state != nil {
+		f.state.mu.Lock()
+		f.state.revision = contentRevision(data)
+		f.state.mu.Unlock()
+	}
+
+	return &fileReader{Reader: bytes.NewReader(data), name: f.file}, nil
+}
+
+// Watch implements the Watcher interface. It checks the file at the poll
+// interval and sends an event when its content changes. A file that is
+// missing or unreadable is checked again at the next tick.
+func (f File) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	baseline := f.Revision()
+
+	if baseline == "" {
+		data, err := f.read()
+		if err == nil {
+			baseline = contentRevision(data)
+		}
+	}
+
+	digest := func() (config.RevisionType, error) {
+		data, err := f.read()
+		if err != nil {
+			return "", err
+		}
+
+		return contentRevision(data), nil
+	}
+
+	event := WatchEvent{Prefix: f.file, Keys: nil, Revision: ""}
+
+	return pollChanges(ctx, f.interval, f.debounce, baseline, digest, event), nil
+}
+
+// read reads the content of the file.
+func (f File) read() ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if f.fsys != nil {
+		data, err = fs.ReadFile(f.fsys, f.file)
+	} else {
+		data, err = os.ReadFile(filepath.Clean(f.file))
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("%w %s: %w", ErrFile, f.file, err)
 	}
 
-	return reader, nil
+	return data, nil
+}
+
+// fileReader is the content of a file, with its name, so that formats can
+// detect it by extension.
+type fileReader struct {
+	*bytes.Reader
+
+	name string
+}
+
+// Name returns the name of the file.
+func (r *fileReader) Name() string {
+	return r.name
+}
+
+// Close implements io.Closer.
+func (r *fileReader) Close() error {
+	return nil
 }
 
 // Source represent data source with format.
@@ -67,30 +202,49 @@ type Source struct {
 	source DataSource
 	format Format
 	node   *tree.Node
+	mu     sync.RWMutex
 }
 
 // NewSource returns new Source object.
+//
+// When the DataSource also implements [Watcher] (e.g., [StorageSource]), the
+// returned collector implements it too: every change notification refetches
+// and reparses the source before it is forwarded, so a [config.Reloader]
+// rebuilding on that event reads the new content.
 func NewSource(ctx context.Context, source DataSource, format Format) (config.Collector, error) {
-	var err error
+	node, err := fetchAndParse(ctx, source, format)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &Source{
+		source: source,
+		format: format,
+		node:   node,
+		mu:     sync.RWMutex{},
+	}
+
+	if watcher, ok := source.(Watcher); ok {
+		return &watchedSource{source: src, watcher: watcher, mu: sync.RWMutex{}, err: nil}, nil
+	}
 
+	return src, nil
+}
+
+// fetchAndParse fetches the source stream and parses it with format.
+func fetchAndParse(ctx context.Context, source DataSource, format Format) (*tree.Node, error) {
 	reader, err := source.FetchStream(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFetchStream, err)
 	}
 	defer reader.Close() //nolint:errcheck
 
-	format = format.From(reader)
-
-	node, err := format.Parse()
+	node, err := format.From(reader).Parse()
 	if err != nil {
 		return nil, NewFormatParseError(source.Name(), err)
 	}
 
-	return &Source{
-		source: source,
-		format: format,
-		node:   node,
-	}, nil
+	return node, nil
 }
 
 // Name implements Collector interface.
@@ -120,10 +274,111 @@ func (s *Source) Read(ctx context.Context) <-chan config.Value {
 	go func() {
 		defer close(channel)
 
+		s.mu.RLock()
+		node := s.node
+		s.mu.RUnlock()
+
 		// Walk the tree and send leaf values.
 		// For simplicity, we traverse recursively.
-		walkTree(ctx, s.node, config.NewKeyPath(""), channel)
+		walkTree(ctx, node, config.NewKeyPath(""), channel)
 	}()
 
 	return channel
 }
+
+// refresh refetches and reparses the underlying data source, replacing the
+// parsed tree on success.
+func (s *Source) refresh(ctx context.Context) error {
+	node, err := fetchAndParse(ctx, s.source, s.format)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.node = node
+	s.mu.Unlock()
+
+	return nil
+}
+
+// watchedSource is a Source over a DataSource that implements Watcher.
+type watchedSource struct {
+	source  *Source
+	watcher Watcher
+
+	mu  sync.RWMutex
+	err error
+}
+
+// Name implements Collector interface.
+func (ws *watchedSource) Name() string { return ws.source.Name() }
+
+// Source implements Collector interface.
+func (ws *watchedSource) Source() config.SourceType { return ws.source.Source() }
+
+// Revision implements Collector interface.
+func (ws *watchedSource) Revision() config.RevisionType { return ws.source.Revision() }
+
+// KeepOrder implements Collector interface.
+func (ws *watchedSource) KeepOrder() bool { return ws.source.KeepOrder() }
+
+// Read implements Collector interface.
+func (ws *watchedSource) Read(ctx context.Context) <-chan config.Value { return ws.source.Read(ctx) }
+
+// Collectors implements config.MultiCollector. It returns the error of the
+// last refetch triggered by Watch, if it failed, so that a rebuild on that
+// event is rejected with it instead of silently reusing the previous content.
+// Otherwise the Source itself is the only sub-collector.
+func (ws *watchedSource) Collectors(_ context.Context) ([]config.Collector, error) {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	if ws.err != nil {
+		return nil, ws.err
+	}
+
+	return []config.Collector{ws.source}, nil
+}
+
+// Watch implements the Watcher interface. Each change event of the
+// underlying DataSource triggers a refetch before the event is forwarded. If
+// the refetch fails, the previous content is kept for Read and the error is
+// reported by Collectors until a later refetch succeeds; the event is
+// forwarded either way.
+func (ws *watchedSource) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	rawCh, err := ws.watcher.Watch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch source: %w", err)
+	}
+
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-rawCh:
+				if !ok {
+					return
+				}
+
+				refreshErr := ws.source.refresh(ctx)
+
+				ws.mu.Lock()
+				ws.err = refreshErr
+				ws.mu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					return
+				case eventCh <- event:
+				}
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
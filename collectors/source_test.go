@@ -1,7 +1,12 @@
 package collectors_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,7 +138,11 @@ func TestNewSource_Revision(t *testing.T) {
 	require.NotNil(t, source)
 	require.NoError(t, err)
 
-	assert.Empty(t, source.Revision())
+	data, err := os.ReadFile("testdata/config.yaml")
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(data)
+	assert.Equal(t, config.RevisionType(hex.EncodeToString(sum[:])), source.Revision())
 }
 
 func TestNewSource_KeepOrder(t *testing.T) {
@@ -189,3 +198,29 @@ func TestNewSource_Read(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "http://localhost:2379", dest)
 }
+
+func TestNewFileFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"configs/app.json": &fstest.MapFile{Data: []byte(`{"server": {"port": 3301}}`)},
+	}
+
+	file := collectors.NewFileFS(fsys, "configs/app.json")
+	assert.Equal(t, "file", file.Name())
+	assert.Equal(t, config.FileSource, file.SourceType())
+
+	collector, err := collectors.NewSource(t.Context(), file, collectors.NewAutoFormat(nil))
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+	assert.Equal(t, 3301, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+
+	_, err = collectors.NewFileFS(fsys, "configs/missing.json").FetchStream(t.Context())
+	require.ErrorIs(t, err, collectors.ErrFile)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
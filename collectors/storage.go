@@ -3,8 +3,13 @@ package collectors
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/tree"
@@ -16,16 +21,28 @@ import (
 // verification. Each key's value is parsed according to the given Format
 // and merged into a single config tree. Key names are used only for
 // distinguishing documents; the YAML content determines the tree structure.
+//
+// In flat mode ([Storage.WithFlatKeys]) every key is a single value instead:
+// the key name determines its path and the value is parsed as a YAML scalar.
 type Storage struct {
 	name        string
 	sourceType  config.SourceType
 	revision    config.RevisionType
 	keepOrder   bool
 	skipInvalid bool
+	flat        bool
 	typed       *integrity.Typed[[]byte]
 	format      Format
 	prefix      string
 	delimiter   string
+
+	integrityMode IntegrityMode
+	// mu guards revision, integrity and observed, which Collectors updates.
+	mu        sync.RWMutex
+	integrity []DocumentIntegrity
+
+	watchWindow time.Duration
+	observed    map[string]storageKeyState
 }
 
 // NewStorage creates a new Storage collector that reads all keys under the
@@ -42,10 +59,18 @@ func NewStorage(
 		revision:    "",
 		keepOrder:   false,
 		skipInvalid: false,
+		flat:        false,
 		typed:       typed,
 		format:      format,
 		prefix:      prefix,
 		delimiter:   "/",
+
+		integrityMode: IntegrityIgnore,
+		mu:            sync.RWMutex{},
+		integrity:     nil,
+
+		watchWindow: 0,
+		observed:    nil,
 	}
 }
 
@@ -70,7 +95,10 @@ func (s *Storage) WithSourceType(source config.SourceType) *Storage {
 // If not set, the revision will be derived from the highest ModRevision
 // among the fetched keys after a successful Read.
 func (s *Storage) WithRevision(rev config.RevisionType) *Storage {
+	s.mu.Lock()
 	s.revision = rev
+	s.mu.Unlock()
+
 	return s
 }
 
@@ -92,14 +120,30 @@ func (s *Storage) WithSkipInvalid(skip bool) *Storage {
 }
 
 // WithDelimiter sets the delimiter used to split storage keys into
-// config path segments. The default is "/". If the delimiter differs
-// from "/", it is replaced internally with "/" before constructing
+// config path segments in flat mode. The default is "/". If the delimiter
+// differs from "/", it is replaced internally with "/" before constructing
 // the KeyPath.
 func (s *Storage) WithDelimiter(delim string) *Storage {
 	s.delimiter = delim
 	return s
 }
 
+// WithFlatKeys sets whether every storage key holds a single value instead of
+// a whole document (default false). In flat mode the key name, relative to
+// the prefix and split by the delimiter, is the path of the value, so with
+// prefix "/app/config/" the key "/app/config/iproto/listen" sets
+// iproto/listen. Values are parsed as YAML scalars ("3301" is a number,
+// "true" a boolean); a value holding a YAML mapping or sequence sets the
+// subtree at that path. The collector's Format is not used and may be nil.
+//
+// Every key is merged as its own sub-collector, so each value reports the
+// ModRevision of its key as MetaInfo.Revision. Integrity verification works
+// as for documents, per key.
+func (s *Storage) WithFlatKeys(flat bool) *Storage {
+	s.flat = flat
+	return s
+}
+
 // Name returns the collector's name.
 func (s *Storage) Name() string {
 	return s.name
@@ -112,6 +156,9 @@ func (s *Storage) Source() config.SourceType {
 
 // Revision returns the collector's current revision.
 func (s *Storage) Revision() config.RevisionType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.revision
 }
 
@@ -132,11 +179,12 @@ func (s *Storage) SkipInvalid() bool {
 // sub-collector is merged independently by the Builder with its own
 // MergerContext, source name, and revision.
 // The parent Storage's revision is updated to the maximum ModRevision among
-// the fetched keys.
+// the fetched keys, or "0" if the prefix is empty.
 // Keys with empty values are skipped. By default, a parse error on any key
 // causes Collectors to return a *FormatParseError that identifies the
 // offending key; use WithSkipInvalid(true) to silently skip invalid
-// documents instead.
+// documents instead. Documents failing integrity verification are handled
+// according to the integrity mode (see [Storage.WithIntegrityMode]).
 func (s *Storage) Collectors(ctx context.Context) ([]config.Collector, error) {
 	results, err := s.typed.Range(ctx, "",
 		integrity.IgnoreVerificationError())
@@ -144,7 +192,48 @@ func (s *Storage) Collectors(ctx context.Context) ([]config.Collector, error) {
 		return nil, fmt.Errorf("storage range failed: %w", err)
 	}
 
+	slices.SortFunc(results, func(a, b integrity.ValidatedResult[[]byte]) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	report := make([]DocumentIntegrity, 0, len(results))
+	observed := make(map[string]storageKeyState, len(results))
+
+	var failures []error
+
+	for _, result := range results {
+		status := DocumentIntegrity{
+			Key:      s.prefix + result.Name,
+			Source:   s.sourceName(result.Name),
+			Revision: config.RevisionType(strconv.FormatInt(result.ModRevision, 10)),
+			Err:      nil,
+		}
+
+		if result.Error != nil {
+			status.Err = NewIntegrityError(status.Key, result.Error)
+			failures = append(failures, status.Err)
+		}
+
+		report = append(report, status)
+		observed[result.Name] = storageKeyState{
+			revision: result.ModRevision,
+			verified: result.Error == nil,
+		}
+	}
+
+	s.mu.Lock()
+	s.integrity, s.observed = report, observed
+	s.mu.Unlock()
+
+	if s.integrityMode == IntegrityStrict && len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+
 	if len(results) == 0 {
+		s.mu.Lock()
+		s.revision = "0"
+		s.mu.Unlock()
+
 		return nil, nil
 	}
 
@@ -157,6 +246,10 @@ func (s *Storage) Collectors(ctx context.Context) ([]config.Collector, error) {
 			maxRev = result.ModRevision
 		}
 
+		if result.Error != nil && s.integrityMode == IntegrityReport {
+			continue
+		}
+
 		if result.Value.IsZero() {
 			continue
 		}
@@ -167,9 +260,7 @@ func (s *Storage) Collectors(ctx context.Context) ([]config.Collector, error) {
 			continue
 		}
 
-		format := s.format.From(bytes.NewReader(value))
-
-		subtree, parseErr := format.Parse()
+		subtree, parseErr := s.parse(result.Name, value)
 		if parseErr != nil {
 			if s.skipInvalid {
 				continue
@@ -190,7 +281,9 @@ func (s *Storage) Collectors(ctx context.Context) ([]config.Collector, error) {
 		})
 	}
 
+	s.mu.Lock()
 	s.revision = config.RevisionType(strconv.FormatInt(maxRev, 10))
+	s.mu.Unlock()
 
 	return docs, nil
 }
@@ -224,6 +317,36 @@ func (s *Storage) Read(ctx context.Context) <-chan config.Value {
 	return valueChan
 }
 
+// parse parses the value of the named key into the tree it contributes.
+func (s *Storage) parse(name string, value []byte) (*tree.Node, error) {
+	if !s.flat {
+		return s.format.From(bytes.NewReader(value)).Parse() //nolint:wrapcheck
+	}
+
+	path := splitKey(name, s.delimiter)
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: key %q has an empty path", ErrUnmarshall, name)
+	}
+
+	leaf, err := NewYamlFormat().From(bytes.NewReader(value)).Parse()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	root := tree.New()
+	node := root
+
+	for _, segment := range path[:len(path)-1] {
+		child := tree.New()
+		node.SetChild(segment, child)
+		node = child
+	}
+
+	node.SetChild(path[len(path)-1], leaf)
+
+	return root, nil
+}
+
 // storageDocument is an unexported Collector wrapping a single parsed
 // configuration document from storage. Each document is merged independently.
 type storageDocument struct {
@@ -259,40 +382,6 @@ func (d *storageDocument) Revision() config.RevisionType { return d.revision }
 // KeepOrder returns whether key order should be preserved.
 func (d *storageDocument) KeepOrder() bool { return d.keepOrder }
 
-// Watch implements the Watcher interface. It returns a channel that streams
-// change events for the configured prefix in storage.
-func (s *Storage) Watch(ctx context.Context) (<-chan WatchEvent, error) {
-	rawCh, err := s.typed.Watch(ctx, "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to watch storage: %w", err)
-	}
-
-	eventCh := make(chan WatchEvent)
-
-	go func() {
-		defer close(eventCh)
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case _, ok := <-rawCh:
-				if !ok {
-					return
-				}
-
-				select {
-				case <-ctx.Done():
-					return
-				case eventCh <- WatchEvent{Prefix: s.prefix}:
-				}
-			}
-		}
-	}()
-
-	return eventCh, nil
-}
-
 // sourceName builds the source identifier for a specific storage key.
 // The format is "<name>:<prefix><key>".
 func (s *Storage) sourceName(key string) string {
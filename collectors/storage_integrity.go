@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"github.com/tarantool/go-config"
+)
+
+// IntegrityMode defines how the [Storage] collector handles documents that
+// fail integrity verification: a hash or signature that does not match, or
+// that is missing for a configured hasher or verifier.
+type IntegrityMode int
+
+const (
+	// IntegrityIgnore merges documents that fail verification like verified
+	// ones. It is the default.
+	IntegrityIgnore IntegrityMode = iota
+	// IntegrityReport skips documents that fail verification without failing
+	// Collectors. The failures are reported by [Storage.Integrity].
+	IntegrityReport
+	// IntegrityStrict fails Collectors, and so the build, if any document
+	// fails verification. The error joins an [IntegrityError] per document.
+	IntegrityStrict
+)
+
+// DocumentIntegrity is the integrity verification status of a storage
+// document.
+type DocumentIntegrity struct {
+	// Key is the storage key of the document (prefix and name).
+	Key string
+	// Source is the source name of the values read from the document, as
+	// reported in MetaInfo.Source.Name.
+	Source string
+	// Revision is the ModRevision of the document, as reported in
+	// MetaInfo.Revision.
+	Revision config.RevisionType
+	// Err is an [IntegrityError] if the document failed verification, nil
+	// if every configured hash and signature was verified.
+	Err error
+}
+
+// Verified reports whether the document passed integrity verification.
+func (d DocumentIntegrity) Verified() bool {
+	return d.Err == nil
+}
+
+// WithIntegrityMode sets how documents failing integrity verification are
+// handled (default [IntegrityIgnore]). Verification is performed by the
+// integrity.Typed storage with its hashers and verifiers; without them every
+// document passes. Documents whose value cannot be decoded are dropped by the
+// integrity layer before they reach the collector.
+func (s *Storage) WithIntegrityMode(mode IntegrityMode) *Storage {
+	s.integrityMode = mode
+	return s
+}
+
+// Integrity returns the verification status of every document read by the
+// last Collectors call, in storage key order, whatever the integrity mode.
+func (s *Storage) Integrity() []DocumentIntegrity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]DocumentIntegrity(nil), s.integrity...)
+}
+
+// IntegrityOf returns the verification status of the document a value was
+// read from, given the value's metadata. It returns false if the value does
+// not come from a document read by the last Collectors call of this
+// collector, e.g. because it comes from another source or from an older
+// revision of the document. An audit can check that every value of a
+// configuration comes from a verified document:
+//
+//	values, _ := cfg.Walk(ctx, nil, 0)
+//	for value := range values {
+//		status, ok := storage.IntegrityOf(value.Meta())
+//		if !ok || !status.Verified() {
+//			// The value is not backed by a verified document.
+//		}
+//	}
+func (s *Storage) IntegrityOf(meta config.MetaInfo) (DocumentIntegrity, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, status := range s.integrity {
+		if status.Source == meta.Source.Name && status.Revision == meta.Revision {
+			return status, true
+		}
+	}
+
+	return DocumentIntegrity{Key: "", Source: "", Revision: "", Err: nil}, false
+}
@@ -0,0 +1,328 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-storage"
+	"github.com/tarantool/go-storage/crypto"
+	"github.com/tarantool/go-storage/hasher"
+	"github.com/tarantool/go-storage/integrity"
+	"github.com/tarantool/go-storage/kv"
+	"github.com/tarantool/go-storage/marshaller"
+	"github.com/tarantool/go-storage/namer"
+	"github.com/tarantool/go-storage/operation"
+	"github.com/tarantool/go-storage/predicate"
+)
+
+// StoragePublisher writes configuration documents into a key-value storage
+// under a common prefix, in the layout read by [Storage] and [StorageSource].
+// Every document is stored with integrity metadata: a hash per configured
+// hasher and a signature per configured signer, generated by the integrity
+// layer exactly as integrity.Typed does with the same namer and marshaller.
+//
+// All keys of a publication are written in a single transaction, so readers
+// never observe a partially published configuration.
+type StoragePublisher struct {
+	storage    storage.Storage
+	prefix     string
+	hashers    []hasher.Hasher
+	signers    []crypto.Signer
+	namerFunc  integrity.NamerConstructor
+	marshaller marshaller.TypedMarshaller[[]byte]
+
+	namer       namer.Namer
+	generator   integrity.Generator[[]byte]
+	deleteStale bool
+}
+
+// NewStoragePublisher creates a new StoragePublisher that writes documents
+// under the prefix (e.g., "/config/") of the given storage, hashing them with
+// the hashers and signing them with the signers. Use the same prefix and
+// hashers, and verifiers matching the signers, to read the documents back.
+//
+// The keys are laid out by the default namer and the documents are stored
+// as is, as by an integrity.TypedBuilder with a pass-through marshaller (see
+// marshaller.NewTypedBytesMarshaller) and no custom namer. Use
+// [StoragePublisher.WithNamer] and [StoragePublisher.WithMarshaller] to match
+// readers built otherwise.
+//
+// The publisher takes the hashers and signers rather than the
+// *integrity.Typed the readers are built with: Typed.Put writes a single
+// document per transaction, with predicates on that document only, so a
+// publication of several documents, a revision check covering the whole
+// prefix and the deletion of stale keys cannot be committed atomically
+// through it, and a built Typed does not expose its configuration.
+func NewStoragePublisher(
+	strg storage.Storage,
+	prefix string,
+	hashers []hasher.Hasher,
+	signers []crypto.Signer,
+) *StoragePublisher {
+	publisher := &StoragePublisher{
+		storage:    strg,
+		prefix:     prefix,
+		hashers:    hashers,
+		signers:    signers,
+		namerFunc:  namer.NewDefaultNamer,
+		marshaller: rawBytesMarshaller{},
+
+		namer:       nil,
+		generator:   integrity.Generator[[]byte]{},
+		deleteStale: false,
+	}
+	publisher.init()
+
+	return publisher
+}
+
+// WithNamer sets the constructor of the namer that lays out the keys, as set
+// by integrity.TypedBuilder.WithNamer for the readers. It is called with the
+// prefix and the names of the hashers and signers.
+func (p *StoragePublisher) WithNamer(namerFunc integrity.NamerConstructor) *StoragePublisher {
+	p.namerFunc = namerFunc
+	p.init()
+
+	return p
+}
+
+// WithMarshaller sets the marshaller that encodes the documents before they
+// are stored, as set by integrity.TypedBuilder.WithMarshaller for the
+// readers (default: none, the documents are stored as is).
+func (p *StoragePublisher) WithMarshaller(m marshaller.TypedMarshaller[[]byte]) *StoragePublisher {
+	p.marshaller = m
+	p.init()
+
+	return p
+}
+
+// init builds the namer and the integrity generator from the configuration.
+func (p *StoragePublisher) init() {
+	p.namer = p.namerFunc(p.prefix, hasherNames(p.hashers), signerNames(p.signers))
+	p.generator = integrity.NewGenerator[[]byte](p.namer, p.marshaller, p.hashers, p.signers)
+}
+
+// WithDeleteStale sets whether a publication removes all other keys under
+// the prefix (default false): documents that are not part of it and
+// integrity keys that it did not write, e.g. the signature of a signer that
+// is no longer configured. They are deleted in the same transaction.
+func (p *StoragePublisher) WithDeleteStale(deleteStale bool) *StoragePublisher {
+	p.deleteStale = deleteStale
+	return p
+}
+
+// Publish writes the documents, keyed by document name, under the prefix.
+// The content is stored as is, so it must be in the format the readers
+// expect (e.g., YAML for a [Storage] collector with [NewYamlFormat]).
+//
+// If expected is not empty, the documents are written only if the revision
+// of the prefix is still expected: the revision reported by [Storage] after
+// reading the prefix, that is the highest ModRevision among its documents,
+// or "0" for an empty prefix. Otherwise, or if a document under the prefix
+// changes before the transaction is committed, nothing is written and an
+// error wrapping ErrRevisionConflict is returned.
+func (p *StoragePublisher) Publish(
+	ctx context.Context,
+	docs map[string][]byte,
+	expected config.RevisionType,
+) error {
+	var puts []kv.KeyValue
+
+	for _, name := range slices.Sorted(maps.Keys(docs)) {
+		kvs, err := p.generator.Generate(name, docs[name])
+		if err != nil {
+			return fmt.Errorf("%w: document %q: %w", ErrStoragePublish, name, err)
+		}
+
+		puts = append(puts, kvs...)
+	}
+
+	var existing []kv.KeyValue
+
+	if expected != "" || p.deleteStale {
+		var err error
+
+		existing, err = p.existing(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var predicates []predicate.Predicate
+
+	if expected != "" {
+		var err error
+
+		predicates, err = p.revisionPredicates(existing, docs, expected)
+		if err != nil {
+			return err
+		}
+	}
+
+	ops := make([]operation.Operation, 0, len(puts)+len(existing))
+	written := make(map[string]bool, len(puts))
+
+	for _, entry := range puts {
+		ops = append(ops, operation.Put(entry.Key, entry.Value))
+		written[string(entry.Key)] = true
+	}
+
+	if p.deleteStale {
+		for _, entry := range existing {
+			if !written[string(entry.Key)] {
+				ops = append(ops, operation.Delete(entry.Key))
+			}
+		}
+	}
+
+	txn := p.storage.Tx(ctx)
+	if len(predicates) > 0 {
+		txn = txn.If(predicates...)
+	}
+
+	resp, err := txn.Then(ops...).Commit()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStoragePublish, err)
+	}
+
+	if len(predicates) > 0 && !resp.Succeeded {
+		return fmt.Errorf("%w: prefix %q changed during publication", ErrRevisionConflict, p.prefix)
+	}
+
+	return nil
+}
+
+// PublishConfig writes the configuration as a single YAML document with the
+// given name. The configuration is serialized with
+// [config.Config.MarshalCanonicalYAML], which masks the values of sensitive
+// keys, so a configuration with a key marked by [config.Builder.WithSensitive]
+// is rejected with ErrStoragePublish rather than published masked; publish a
+// configuration built without WithSensitive to store such values. See
+// [StoragePublisher.Publish] for expected.
+func (p *StoragePublisher) PublishConfig(
+	ctx context.Context,
+	name string,
+	cfg *config.Config,
+	expected config.RevisionType,
+) error {
+	if key, ok := sensitiveKey(ctx, cfg); ok {
+		return fmt.Errorf("%w: document %q: key %s is sensitive and would be published masked",
+			ErrStoragePublish, name, key)
+	}
+
+	data, err := cfg.MarshalCanonicalYAML()
+	if err != nil {
+		return fmt.Errorf("%w: document %q: %w", ErrStoragePublish, name, err)
+	}
+
+	return p.Publish(ctx, map[string][]byte{name: data}, expected)
+}
+
+// sensitiveKey returns the first key of cfg marked sensitive.
+func sensitiveKey(ctx context.Context, cfg *config.Config) (config.KeyPath, bool) {
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	values, err := cfg.Walk(walkCtx, nil, -1)
+	if err != nil {
+		return nil, false
+	}
+
+	for value := range values {
+		if key := value.Meta().Key; cfg.IsSensitive(key) {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+// existing returns all keys currently stored under the prefix.
+func (p *StoragePublisher) existing(ctx context.Context) ([]kv.KeyValue, error) {
+	prefixes := p.namer.Prefixes("", true)
+
+	ops := make([]operation.Operation, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		ops = append(ops, operation.Get([]byte(prefix)))
+	}
+
+	resp, err := p.storage.Tx(ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageRange, err)
+	}
+
+	var kvs []kv.KeyValue
+	for _, r := range resp.Results {
+		kvs = append(kvs, r.Values...)
+	}
+
+	return kvs, nil
+}
+
+// revisionPredicates checks that the revision of the prefix is expected and
+// returns the transaction predicates that keep it so until the commit: every
+// existing document and every new document of the publication must be
+// unchanged.
+func (p *StoragePublisher) revisionPredicates(
+	existing []kv.KeyValue,
+	docs map[string][]byte,
+	expected config.RevisionType,
+) ([]predicate.Predicate, error) {
+	var (
+		maxRev     int64
+		predicates []predicate.Predicate
+	)
+
+	present := make(map[string]bool)
+
+	for _, entry := range existing {
+		key, err := p.namer.ParseKey(string(entry.Key))
+		if err != nil || key.Type() != namer.KeyTypeValue {
+			continue
+		}
+
+		maxRev = max(maxRev, entry.ModRevision)
+		present[key.Name()] = true
+		predicates = append(predicates, predicate.VersionEqual(entry.Key, entry.ModRevision))
+	}
+
+	current := config.RevisionType(strconv.FormatInt(maxRev, 10))
+	if current != expected {
+		return nil, fmt.Errorf("%w: prefix %q has revision %s, expected %s",
+			ErrRevisionConflict, p.prefix, current, expected)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(docs)) {
+		if present[name] {
+			continue
+		}
+
+		keys, err := p.namer.GenerateNames(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: document %q: %w", ErrStoragePublish, name, err)
+		}
+
+		// Version 0 requires the key to be absent.
+		predicates = append(predicates, predicate.VersionEqual([]byte(keys[0].Build()), 0))
+	}
+
+	return predicates, nil
+}
+
+// signerNames extracts names from a slice of signers.
+func signerNames(signers []crypto.Signer) []string {
+	if len(signers) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(signers))
+	for _, s := range signers {
+		names = append(names, s.Name())
+	}
+
+	return names
+}
@@ -0,0 +1,312 @@
+package collectors_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-storage/crypto"
+	"github.com/tarantool/go-storage/hasher"
+	"github.com/tarantool/go-storage/integrity"
+	"github.com/tarantool/go-storage/marshaller"
+	"github.com/tarantool/go-storage/namer"
+	"github.com/tarantool/go-storage/operation"
+	"github.com/tarantool/go-storage/predicate"
+	"github.com/tarantool/go-storage/tx"
+)
+
+func TestStoragePublisher_Publish_Signed(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	signer := crypto.NewRSAPSSSignerVerifier(*key)
+
+	mock := testutil.NewMockStorage()
+	publisher := collectors.NewStoragePublisher(mock, "/config/",
+		[]hasher.Hasher{hasher.NewSHA256Hasher()}, []crypto.Signer{signer})
+
+	err = publisher.Publish(t.Context(), map[string][]byte{
+		"app":   []byte("server:\n  port: 8080\n"),
+		"extra": []byte("log:\n  level: info\n"),
+	}, "")
+	require.NoError(t, err)
+
+	typed := testutil.NewRawTypedBuilder(mock, "/config/").
+		WithHasher(hasher.NewSHA256Hasher()).
+		WithSignerVerifier(signer).
+		Build()
+
+	// Range drops values that fail verification.
+	results, err := typed.Range(t.Context(), "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewStorage(typed, "/config/", collectors.NewYamlFormat()))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "info", config.MustGet[string](&cfg, config.NewKeyPath("log/level")))
+}
+
+// layeredNamer lays out the documents under "docs" below the prefix, with
+// one hash location per hasher.
+func layeredNamer(t *testing.T) integrity.NamerConstructor {
+	t.Helper()
+
+	return func(prefix string, hashNames []string, _ []string) namer.Namer {
+		hashes := make([]namer.LayeredHashLocation, 0, len(hashNames))
+		for _, name := range hashNames {
+			hashes = append(hashes, namer.LayeredHashLocation{HasherName: name, Location: name})
+		}
+
+		layered, err := namer.NewLayeredNamer("docs", hashes, nil,
+			namer.WithKeyPrefix(strings.TrimSuffix(prefix, "/")))
+		require.NoError(t, err)
+
+		return layered
+	}
+}
+
+func TestStoragePublisher_WithNamer(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	publisher := collectors.NewStoragePublisher(mock, "/config/",
+		[]hasher.Hasher{hasher.NewSHA256Hasher()}, nil).
+		WithNamer(layeredNamer(t)).
+		WithMarshaller(marshaller.NewTypedYamlMarshaller[[]byte]())
+
+	err := publisher.Publish(t.Context(), map[string][]byte{"app": []byte("server:\n  port: 8080\n")}, "")
+	require.NoError(t, err)
+
+	resp, err := mock.Tx(t.Context()).Then(operation.Get([]byte("/config/docs/app"))).Commit()
+	require.NoError(t, err)
+	require.Len(t, resp.Results[0].Values, 1)
+
+	// A reader with the same namer and the default marshaller of the builder.
+	typed := integrity.NewTypedBuilder[[]byte](mock).
+		WithPrefix("/config/").
+		WithHasher(hasher.NewSHA256Hasher()).
+		WithNamer(layeredNamer(t)).
+		Build()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewStorage(typed, "/config/", collectors.NewYamlFormat()))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+}
+
+func TestStoragePublisher_Publish_ExpectedRevision(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	publisher := collectors.NewStoragePublisher(mock, "/config/", nil, nil)
+
+	collector := collectors.NewStorage(testutil.NewRawTyped(mock, "/config/"), "/config/",
+		collectors.NewYamlFormat())
+
+	_, err := collector.Collectors(t.Context())
+	require.NoError(t, err)
+
+	// An empty prefix is at revision "0".
+	revision := collector.Revision()
+	require.Equal(t, config.RevisionType("0"), revision)
+
+	err = publisher.Publish(t.Context(), map[string][]byte{"app": []byte("a: 1\n")}, revision)
+	require.NoError(t, err)
+
+	err = publisher.Publish(t.Context(), map[string][]byte{"app": []byte("a: 2\n")}, revision)
+	require.ErrorIs(t, err, collectors.ErrRevisionConflict)
+
+	_, err = collector.Collectors(t.Context())
+	require.NoError(t, err)
+
+	revision = collector.Revision()
+
+	err = publisher.Publish(t.Context(), map[string][]byte{"app": []byte("a: 2\n")}, revision)
+	require.NoError(t, err)
+
+	// The revision read before the previous publication is stale now.
+	err = publisher.Publish(t.Context(), map[string][]byte{"app": []byte("a: 3\n")}, revision)
+	require.ErrorIs(t, err, collectors.ErrRevisionConflict)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+	assert.Equal(t, 2, config.MustGet[int](&cfg, config.NewKeyPath("a")))
+}
+
+// racingStorage writes a key right before a conditional transaction is
+// committed, as a concurrent writer would.
+type racingStorage struct {
+	*testutil.MockStorage
+
+	key, value []byte
+}
+
+func (s racingStorage) Tx(ctx context.Context) tx.Tx {
+	return &racingTx{Tx: s.MockStorage.Tx(ctx), storage: s, conditional: false}
+}
+
+type racingTx struct {
+	tx.Tx
+
+	storage     racingStorage
+	conditional bool
+}
+
+func (t *racingTx) If(predicates ...predicate.Predicate) tx.Tx {
+	t.conditional = true
+	t.Tx = t.Tx.If(predicates...)
+
+	return t
+}
+
+func (t *racingTx) Then(ops ...operation.Operation) tx.Tx {
+	t.Tx = t.Tx.Then(ops...)
+	return t
+}
+
+func (t *racingTx) Commit() (tx.Response, error) {
+	if t.conditional {
+		t.storage.Put(t.storage.key, t.storage.value)
+	}
+
+	return t.Tx.Commit() //nolint:wrapcheck
+}
+
+func TestStoragePublisher_Publish_ConcurrentChange(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"/config/app", "/config/new"} {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+
+			mock := testutil.NewMockStorage()
+			testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 1\n"))
+
+			strg := racingStorage{MockStorage: mock, key: []byte(key), value: []byte("a: 2\n")}
+			publisher := collectors.NewStoragePublisher(strg, "/config/", nil, nil)
+
+			err := publisher.Publish(t.Context(), map[string][]byte{
+				"app": []byte("a: 3\n"),
+				"new": []byte("b: 3\n"),
+			}, "1")
+			require.ErrorIs(t, err, collectors.ErrRevisionConflict)
+
+			resp, err := mock.Tx(t.Context()).Then(operation.Get([]byte(key))).Commit()
+			require.NoError(t, err)
+			require.Len(t, resp.Results[0].Values, 1)
+			assert.Equal(t, []byte("a: 2\n"), resp.Results[0].Values[0].Value)
+		})
+	}
+}
+
+func TestStoragePublisher_WithDeleteStale(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	hashers := []hasher.Hasher{hasher.NewSHA256Hasher()}
+
+	publisher := collectors.NewStoragePublisher(mock, "/config/", hashers, nil)
+
+	err := publisher.Publish(t.Context(), map[string][]byte{
+		"a": []byte("a: 1\n"),
+		"b": []byte("b: 1\n"),
+	}, "")
+	require.NoError(t, err)
+
+	err = publisher.WithDeleteStale(true).
+		Publish(t.Context(), map[string][]byte{"a": []byte("a: 2\n")}, "")
+	require.NoError(t, err)
+
+	resp, err := mock.Tx(t.Context()).Then(operation.Get([]byte("/config/"))).Commit()
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(resp.Results[0].Values))
+	for _, entry := range resp.Results[0].Values {
+		keys = append(keys, string(entry.Key))
+	}
+
+	assert.Equal(t, []string{"/config/a", "/config/hashes/sha256/a"}, keys)
+}
+
+func TestStoragePublisher_PublishConfig(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"replication": map[string]any{"failover": "manual"},
+		"roles":       []any{"router", "storage"},
+	}))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	mock := testutil.NewMockStorage()
+	publisher := collectors.NewStoragePublisher(mock, "/config/", nil, nil)
+
+	require.NoError(t, publisher.PublishConfig(t.Context(), "cluster", &cfg, ""))
+
+	builder = config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewStorage(testutil.NewRawTyped(mock, "/config/"), "/config/",
+		collectors.NewYamlFormat()))
+
+	published, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+	assert.Equal(t, "manual", config.MustGet[string](&published, config.NewKeyPath("replication/failover")))
+	assert.Equal(t, []string{"router", "storage"},
+		config.MustGet[[]string](&published, config.NewKeyPath("roles")))
+}
+
+func TestStoragePublisher_PublishConfig_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"credentials": map[string]any{"password": "s3cr3t"},
+		"log":         map[string]any{"level": "info"},
+	}))
+	builder = builder.WithSensitive("credentials/password")
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	mock := testutil.NewMockStorage()
+	publisher := collectors.NewStoragePublisher(mock, "/config/", nil, nil)
+
+	err := publisher.PublishConfig(t.Context(), "cluster", &cfg, "")
+	require.ErrorIs(t, err, collectors.ErrStoragePublish)
+	assert.Contains(t, err.Error(), "credentials/password")
+
+	resp, err := mock.Tx(t.Context()).Then(operation.Get([]byte("/config/"))).Commit()
+	require.NoError(t, err)
+	assert.Empty(t, resp.Results[0].Values)
+}
+
+func TestStoragePublisher_Publish_TxError(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage().WithTxError(errTestTxFailure)
+	publisher := collectors.NewStoragePublisher(mock, "/config/", nil, nil)
+
+	err := publisher.Publish(t.Context(), map[string][]byte{"app": []byte("a: 1\n")}, "")
+	require.ErrorIs(t, err, collectors.ErrStoragePublish)
+	require.ErrorIs(t, err, errTestTxFailure)
+}
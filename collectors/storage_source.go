@@ -85,7 +85,8 @@ func (s *StorageSource) Revision() config.RevisionType {
 // integrity verification and returns an io.ReadCloser over the raw value bytes.
 // If the key is not found, ErrStorageKeyNotFound is returned. Storage errors
 // are wrapped with ErrStorageFetch. Integrity verification errors are wrapped
-// with ErrStorageValidation. The revision is updated on success.
+// with ErrStorageValidation; a failed hash or signature check is reported as
+// an *IntegrityError. The revision is updated on success.
 func (s *StorageSource) FetchStream(ctx context.Context) (io.ReadCloser, error) {
 	keys, err := s.namer.GenerateNames(s.name)
 	if err != nil {
@@ -122,7 +123,7 @@ func (s *StorageSource) FetchStream(ctx context.Context) (io.ReadCloser, error)
 
 	result := results[0]
 	if result.Error != nil {
-		return nil, fmt.Errorf("%w: %w", ErrStorageValidation, result.Error)
+		return nil, NewIntegrityError(keys[0].Build(), result.Error)
 	}
 
 	if result.Value.IsZero() {
@@ -161,7 +162,7 @@ func (s *StorageSource) Watch(ctx context.Context) (<-chan WatchEvent, error) {
 				select {
 				case <-ctx.Done():
 					return
-				case eventCh <- WatchEvent{Prefix: s.name}:
+				case eventCh <- WatchEvent{Prefix: s.name, Keys: nil, Revision: ""}:
 				}
 			}
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/collectors"
 	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-storage/hasher"
 )
 
 var errTestTxFailure = errors.New("tx failed")
@@ -225,3 +226,21 @@ func TestStorageSource_WithSource_InvalidYaml(t *testing.T) {
 
 	require.ErrorAs(t, err, &fpErr)
 }
+
+func TestStorageSource_FetchStream_IntegrityError(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("a: 1\n"))
+
+	source := collectors.NewStorageSource(mock, "/config/", "app",
+		[]hasher.Hasher{hasher.NewSHA256Hasher()}, nil)
+
+	_, err := source.FetchStream(t.Context())
+	require.ErrorIs(t, err, collectors.ErrStorageValidation)
+
+	var integrityErr *collectors.IntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	assert.Equal(t, "/config/app", integrityErr.Key)
+	assert.Equal(t, []string{`hash "sha256" not verified (missing)`}, integrityErr.Failures)
+}
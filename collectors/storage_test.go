@@ -11,6 +11,7 @@ import (
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/collectors"
 	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-storage/hasher"
 )
 
 func TestNewStorage(t *testing.T) {
@@ -477,3 +478,228 @@ func TestStorage_Builder_WithOtherCollectors(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "storage-value", val)
 }
+
+func TestStorage_FlatKeys(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	typed := testutil.NewRawTypedBuilder(mock, "/app/config/").
+		WithHasher(hasher.NewSHA256Hasher()).
+		Build()
+
+	ctx := t.Context()
+
+	require.NoError(t, typed.Put(ctx, "iproto/listen", []byte("0.0.0.0:3301")))
+	require.NoError(t, typed.Put(ctx, "iproto/threads", []byte("4")))
+	require.NoError(t, typed.Put(ctx, "log/level", []byte("info")))
+	require.NoError(t, typed.Put(ctx, "roles", []byte("[router, storage]")))
+	require.NoError(t, typed.Put(ctx, "debug", []byte("true")))
+
+	collector := collectors.NewStorage(typed, "/app/config/", nil).
+		WithName("etcd").
+		WithFlatKeys(true)
+
+	subs, err := collector.Collectors(ctx)
+	require.NoError(t, err)
+	require.Len(t, subs, 5)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(ctx)
+	require.Empty(t, errs)
+
+	assert.Equal(t, "0.0.0.0:3301", config.MustGet[string](&cfg, config.NewKeyPath("iproto/listen")))
+	assert.Equal(t, 4, config.MustGet[int](&cfg, config.NewKeyPath("iproto/threads")))
+	assert.Equal(t, "info", config.MustGet[string](&cfg, config.NewKeyPath("log/level")))
+	assert.Equal(t, []string{"router", "storage"}, config.MustGet[[]string](&cfg, config.NewKeyPath("roles")))
+	assert.True(t, config.MustGet[bool](&cfg, config.NewKeyPath("debug")))
+
+	_, ok := cfg.Lookup(config.NewKeyPath("hash"))
+	assert.False(t, ok)
+
+	listen, ok := cfg.Lookup(config.NewKeyPath("iproto/listen"))
+	require.True(t, ok)
+	assert.Equal(t, "etcd:/app/config/iproto/listen", listen.Meta().Source.Name)
+
+	threads, ok := cfg.Lookup(config.NewKeyPath("iproto/threads"))
+	require.True(t, ok)
+	assert.NotEqual(t, listen.Meta().Revision, threads.Meta().Revision)
+
+	// Updating a key changes the revision of its value only.
+	require.NoError(t, typed.Put(ctx, "iproto/listen", []byte("localhost:3301")))
+
+	cfg, errs = builder.Build(ctx)
+	require.Empty(t, errs)
+
+	updated, ok := cfg.Lookup(config.NewKeyPath("iproto/listen"))
+	require.True(t, ok)
+	assert.NotEqual(t, listen.Meta().Revision, updated.Meta().Revision)
+	assert.Equal(t, collector.Revision(), updated.Meta().Revision)
+
+	same, ok := cfg.Lookup(config.NewKeyPath("iproto/threads"))
+	require.True(t, ok)
+	assert.Equal(t, threads.Meta().Revision, same.Meta().Revision)
+}
+
+func TestStorage_FlatKeys_Delimiter(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/legacy/", "server.port", []byte("8080"))
+	testutil.PutIntegrity(mock, "/legacy/", "server.name", []byte("'007'"))
+
+	typed := testutil.NewRawTyped(mock, "/legacy/")
+	collector := collectors.NewStorage(typed, "/legacy/", nil).
+		WithFlatKeys(true).
+		WithDelimiter(".")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 8080, config.MustGet[int](&cfg, config.NewKeyPath("server/port")))
+	assert.Equal(t, "007", config.MustGet[string](&cfg, config.NewKeyPath("server/name")))
+}
+
+func TestStorage_FlatKeys_ParseError(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "good", []byte("1"))
+	testutil.PutIntegrity(mock, "/config/", "bad", []byte("[unclosed"))
+
+	typed := testutil.NewRawTyped(mock, "/config/")
+	collector := collectors.NewStorage(typed, "/config/", nil).WithFlatKeys(true)
+
+	_, err := collector.Collectors(t.Context())
+
+	var parseErr *collectors.FormatParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "/config/bad", parseErr.Key)
+
+	subs, err := collector.WithSkipInvalid(true).Collectors(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+}
+
+// newTamperedStorage stores the verified document "app" and the document
+// "evil", whose value was changed after it was hashed.
+func newTamperedStorage(t *testing.T) *testutil.MockStorage {
+	t.Helper()
+
+	mock := testutil.NewMockStorage()
+	typed := testutil.NewRawTypedBuilder(mock, "/config/").
+		WithHasher(hasher.NewSHA256Hasher()).
+		Build()
+
+	require.NoError(t, typed.Put(t.Context(), "app", []byte("a: 1\n")))
+	require.NoError(t, typed.Put(t.Context(), "evil", []byte("b: 1\n")))
+
+	mock.Put([]byte("/config/evil"), []byte("b: 2\n"))
+
+	return mock
+}
+
+func newHashedStorage(mock *testutil.MockStorage) *collectors.Storage {
+	typed := testutil.NewRawTypedBuilder(mock, "/config/").
+		WithHasher(hasher.NewSHA256Hasher()).
+		Build()
+
+	return collectors.NewStorage(typed, "/config/", collectors.NewYamlFormat()).WithName("etcd")
+}
+
+func TestStorage_IntegrityStrict(t *testing.T) {
+	t.Parallel()
+
+	collector := newHashedStorage(newTamperedStorage(t)).
+		WithIntegrityMode(collectors.IntegrityStrict)
+
+	_, err := collector.Collectors(t.Context())
+	require.ErrorIs(t, err, collectors.ErrStorageValidation)
+
+	var integrityErr *collectors.IntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	assert.Equal(t, "/config/evil", integrityErr.Key)
+	assert.Equal(t, []string{`hash mismatch for "sha256"`}, integrityErr.Failures)
+	assert.EqualError(t, integrityErr,
+		`storage integrity validation failed: key "/config/evil": hash mismatch for "sha256"`)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	_, errs := builder.Build(t.Context())
+	require.Len(t, errs, 1)
+	require.ErrorAs(t, errs[0], &integrityErr)
+}
+
+func TestStorage_IntegrityReport(t *testing.T) {
+	t.Parallel()
+
+	mock := newTamperedStorage(t)
+	// A document written without integrity metadata.
+	testutil.PutIntegrity(mock, "/config/", "unsigned", []byte("c: 1\n"))
+
+	collector := newHashedStorage(mock).WithIntegrityMode(collectors.IntegrityReport)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	value, ok := cfg.Lookup(config.NewKeyPath("a"))
+	require.True(t, ok)
+
+	status, ok := collector.IntegrityOf(value.Meta())
+	require.True(t, ok)
+	assert.True(t, status.Verified())
+	assert.Equal(t, "/config/app", status.Key)
+	assert.Equal(t, "etcd:/config/app", status.Source)
+
+	for _, path := range []string{"b", "c"} {
+		_, ok := cfg.Lookup(config.NewKeyPath(path))
+		assert.False(t, ok, path)
+	}
+
+	report := collector.Integrity()
+	require.Len(t, report, 3)
+	assert.Equal(t, "/config/app", report[0].Key)
+	assert.True(t, report[0].Verified())
+	assert.Equal(t, "/config/evil", report[1].Key)
+	assert.False(t, report[1].Verified())
+	assert.Equal(t, "/config/unsigned", report[2].Key)
+
+	var integrityErr *collectors.IntegrityError
+	require.ErrorAs(t, report[2].Err, &integrityErr)
+	assert.Equal(t, []string{`hash "sha256" not verified (missing)`}, integrityErr.Failures)
+}
+
+func TestStorage_IntegrityIgnore(t *testing.T) {
+	t.Parallel()
+
+	collector := newHashedStorage(newTamperedStorage(t))
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	value, ok := cfg.Lookup(config.NewKeyPath("b"))
+	require.True(t, ok)
+	assert.Equal(t, "etcd:/config/evil", value.Meta().Source.Name)
+
+	status, ok := collector.IntegrityOf(value.Meta())
+	require.True(t, ok)
+	assert.False(t, status.Verified())
+
+	_, ok = collector.IntegrityOf(config.MetaInfo{
+		Key:      config.NewKeyPath("b"),
+		Source:   config.SourceInfo{Name: "etcd:/config/evil", Type: config.StorageSource},
+		Revision: "1",
+	})
+	assert.False(t, ok)
+}
@@ -0,0 +1,188 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-storage/integrity"
+	"github.com/tarantool/go-storage/watch"
+)
+
+// storageKeyState is the state of a storage key observed by
+// [Storage.Collectors] or [Storage.Watch].
+type storageKeyState struct {
+	revision int64
+	verified bool
+}
+
+// WithWatchWindow sets the window over which [Storage.Watch] coalesces bursts
+// of change notifications (default 0, no coalescing): the first notification
+// opens the window, and the prefix is read once when it closes, so a burst
+// costs a single read and is reported as a single event.
+func (s *Storage) WithWatchWindow(window time.Duration) *Storage {
+	s.watchWindow = window
+	return s
+}
+
+// Watch implements the Watcher interface. It returns a channel that streams
+// change events for the configured prefix in storage.
+//
+// To list the changed keys, Watch reads the whole prefix, values included,
+// after every change notification of the storage (or once per window, see
+// [Storage.WithWatchWindow]) and compares it with the previous read. That
+// is a range read per event on top of the read of the rebuild that the event
+// triggers; a [config.Reloader] rebuilds the whole configuration and does
+// not use the keys.
+//
+// Each event lists the changed storage keys (prefix and name): KeyPut for a
+// created or updated key, or a key whose integrity verification status
+// changed, with its ModRevision, and KeyDelete for a deleted key. The
+// event's revision is the highest ModRevision under the prefix, as reported
+// by [Storage.Revision] after the next read. The first event is relative to
+// the last Collectors call, so it also lists changes made between the build
+// and the subscription. Notifications that change no key, e.g. for the
+// integrity keys written along with a document, are dropped. If the prefix
+// cannot be read, the event lists no keys.
+func (s *Storage) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	rawCh, err := s.typed.Watch(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch storage: %w", err)
+	}
+
+	s.mu.RLock()
+	known := s.observed
+	s.mu.RUnlock()
+
+	if known == nil {
+		known, _ = s.snapshot(ctx)
+	}
+
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		for {
+			var notification watch.Event
+
+			select {
+			case <-ctx.Done():
+				return
+			case received, ok := <-rawCh:
+				if !ok {
+					return
+				}
+
+				notification = received
+			}
+
+			open := true
+			if s.watchWindow > 0 {
+				_, open = collectWatchEvents(ctx, rawCh, notification, s.watchWindow, keepFirstNotification)
+			}
+
+			current, _ := s.snapshot(ctx)
+
+			event, changed := s.watchEvent(known, current)
+			known = current
+
+			if !changed {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+
+			if !open {
+				return
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
+
+// keepFirstNotification merges storage notifications: only the fact that
+// the prefix has changed is used.
+func keepFirstNotification(earlier, _ watch.Event) watch.Event {
+	return earlier
+}
+
+// snapshot reads the state of every key under the prefix.
+func (s *Storage) snapshot(ctx context.Context) (map[string]storageKeyState, error) {
+	results, err := s.typed.Range(ctx, "",
+		integrity.IgnoreVerificationError())
+	if err != nil {
+		return nil, fmt.Errorf("storage range failed: %w", err)
+	}
+
+	states := make(map[string]storageKeyState, len(results))
+	for _, result := range results {
+		states[result.Name] = storageKeyState{
+			revision: result.ModRevision,
+			verified: result.Error == nil,
+		}
+	}
+
+	return states, nil
+}
+
+// watchEvent builds the event for a change from the previous to the current
+// snapshot, either of which is nil if it could not be read. It returns false
+// if no key has changed.
+func (s *Storage) watchEvent(previous, current map[string]storageKeyState) (WatchEvent, bool) {
+	event := WatchEvent{Prefix: s.prefix, Keys: nil, Revision: ""}
+
+	if current == nil {
+		return event, true
+	}
+
+	var maxRev int64
+	for _, state := range current {
+		maxRev = max(maxRev, state.revision)
+	}
+
+	event.Revision = config.RevisionType(strconv.FormatInt(maxRev, 10))
+
+	if previous == nil {
+		return event, true
+	}
+
+	names := slices.Collect(maps.Keys(current))
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		state, ok := current[name]
+
+		switch {
+		case !ok:
+			event.Keys = append(event.Keys, config.KeyEvent{
+				Key:      s.prefix + name,
+				Type:     config.KeyDelete,
+				Revision: "",
+			})
+		case state != previous[name]:
+			event.Keys = append(event.Keys, config.KeyEvent{
+				Key:      s.prefix + name,
+				Type:     config.KeyPut,
+				Revision: config.RevisionType(strconv.FormatInt(state.revision, 10)),
+			})
+		}
+	}
+
+	return event, len(event.Keys) > 0
+}
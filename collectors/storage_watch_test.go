@@ -0,0 +1,210 @@
+package collectors_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-storage/operation"
+	"github.com/tarantool/go-storage/tx"
+	"github.com/tarantool/go-storage/watch"
+)
+
+func TestStorage_Watch_Keys(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "a", []byte("a: 1\n"))
+	testutil.PutIntegrity(mock, "/config/", "b", []byte("b: 1\n"))
+
+	collector := collectors.NewStorage(testutil.NewRawTyped(mock, "/config/"), "/config/",
+		collectors.NewYamlFormat())
+
+	_, err := collector.Collectors(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := collector.Watch(ctx)
+	require.NoError(t, err)
+
+	testutil.PutIntegrity(mock, "/config/", "a", []byte("a: 2\n"))
+	testutil.PutIntegrity(mock, "/config/", "c", []byte("c: 1\n"))
+
+	_, err = mock.Tx(t.Context()).Then(operation.Delete([]byte("/config/b"))).Commit()
+	require.NoError(t, err)
+
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/")})
+
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, collectors.WatchEvent{
+		Prefix: "/config/",
+		Keys: []config.KeyEvent{
+			{Key: "/config/a", Type: config.KeyPut, Revision: "3"},
+			{Key: "/config/b", Type: config.KeyDelete, Revision: ""},
+			{Key: "/config/c", Type: config.KeyPut, Revision: "4"},
+		},
+		Revision: "4",
+	}, event)
+
+	// A notification that changes nothing is dropped.
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/")})
+	requireNoWatchEvent(t, events)
+
+	_, err = collector.Collectors(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, event.Revision, collector.Revision())
+}
+
+func TestStorage_Watch_RangeError(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "a", []byte("a: 1\n"))
+
+	collector := collectors.NewStorage(testutil.NewRawTyped(mock, "/config/"), "/config/",
+		collectors.NewYamlFormat())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := collector.Watch(ctx)
+	require.NoError(t, err)
+
+	mock.WithTxError(errTestTxFailure)
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/a")})
+
+	// The changed keys are unknown.
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, "/config/", event.Prefix)
+	assert.Empty(t, event.Keys)
+	assert.Empty(t, event.Revision)
+}
+
+// countingStorage counts the transactions of a storage, e.g. range reads.
+type countingStorage struct {
+	*testutil.MockStorage
+
+	txs *atomic.Int32
+}
+
+func (s countingStorage) Tx(ctx context.Context) tx.Tx {
+	s.txs.Add(1)
+	return s.MockStorage.Tx(ctx)
+}
+
+func TestStorage_WithWatchWindow(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "a", []byte("a: 1\n"))
+
+	strg := countingStorage{MockStorage: mock, txs: &atomic.Int32{}}
+
+	collector := collectors.NewStorage(testutil.NewRawTyped(strg, "/config/"), "/config/",
+		collectors.NewYamlFormat()).WithWatchWindow(10 * testPollInterval)
+
+	_, err := collector.Collectors(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := collector.Watch(ctx)
+	require.NoError(t, err)
+
+	testutil.PutIntegrity(mock, "/config/", "a", []byte("a: 2\n"))
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/a")})
+
+	testutil.PutIntegrity(mock, "/config/", "b", []byte("b: 1\n"))
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/b")})
+
+	txs := strg.txs.Load()
+
+	event := requireWatchEvent(t, events)
+	assert.Equal(t, []config.KeyEvent{
+		{Key: "/config/a", Type: config.KeyPut, Revision: "2"},
+		{Key: "/config/b", Type: config.KeyPut, Revision: "3"},
+	}, event.Keys)
+	assert.Equal(t, config.RevisionType("3"), event.Revision)
+
+	// The burst is read once.
+	assert.Equal(t, txs+1, strg.txs.Load())
+}
+
+func TestCoalesceWatchEvents(t *testing.T) {
+	t.Parallel()
+
+	put := func(key string, rev config.RevisionType) config.KeyEvent {
+		return config.KeyEvent{Key: key, Type: config.KeyPut, Revision: rev}
+	}
+
+	tests := []struct {
+		name     string
+		events   []collectors.WatchEvent
+		expected collectors.WatchEvent
+	}{
+		{
+			name: "last change wins",
+			events: []collectors.WatchEvent{
+				{Prefix: "/config/", Keys: []config.KeyEvent{put("/config/a", "1")}, Revision: "1"},
+				{Prefix: "/config/", Keys: []config.KeyEvent{put("/config/b", "2")}, Revision: "2"},
+				{Prefix: "/config/", Keys: []config.KeyEvent{
+					{Key: "/config/a", Type: config.KeyDelete, Revision: ""},
+				}, Revision: "2"},
+			},
+			expected: collectors.WatchEvent{
+				Prefix: "/config/",
+				Keys: []config.KeyEvent{
+					{Key: "/config/a", Type: config.KeyDelete, Revision: ""},
+					put("/config/b", "2"),
+				},
+				Revision: "2",
+			},
+		},
+		{
+			name: "unknown keys",
+			events: []collectors.WatchEvent{
+				{Prefix: "/config/", Keys: []config.KeyEvent{put("/config/a", "1")}, Revision: "1"},
+				{Prefix: "/config/", Keys: nil, Revision: ""},
+			},
+			expected: collectors.WatchEvent{Prefix: "/config/", Keys: nil, Revision: "1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			in := make(chan collectors.WatchEvent, len(tc.events))
+			for _, event := range tc.events {
+				in <- event
+			}
+
+			close(in)
+
+			out := collectors.CoalesceWatchEvents(t.Context(), in, time.Second)
+
+			assert.Equal(t, tc.expected, requireWatchEvent(t, out))
+
+			_, ok := <-out
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestCoalesceWatchEvents_NoWindow(t *testing.T) {
+	t.Parallel()
+
+	in := make(chan collectors.WatchEvent)
+
+	assert.Equal(t, (<-chan collectors.WatchEvent)(in), collectors.CoalesceWatchEvents(t.Context(), in, 0))
+}
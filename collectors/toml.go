@@ -0,0 +1,1011 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+)
+
+// TOMLFormat implements Format interface for TOML v1.0 documents.
+//
+// Tables become mapping nodes and arrays (including arrays of tables) become
+// nodes marked as arrays; keys keep their document order and every value gets
+// a tree.Range. Integers are int64, floats float64 and offset date-times
+// time.Time. Local date-times, dates and times have no time zone and are kept
+// as strings in their TOML form, e.g. "1979-05-27T07:32:00".
+type TOMLFormat struct {
+	name      string
+	keepOrder bool
+	data      []byte
+	reader    io.Reader
+}
+
+// NewTOMLFormat return new TOMLFormat object.
+func NewTOMLFormat() Format {
+	return TOMLFormat{
+		name:      "toml",
+		keepOrder: true,
+		data:      nil,
+		reader:    nil,
+	}
+}
+
+// Name implements the Format interface.
+func (t TOMLFormat) Name() string {
+	return t.name
+}
+
+// KeepOrder implements the Format interface.
+func (t TOMLFormat) KeepOrder() bool {
+	return t.keepOrder
+}
+
+// From implements the Format interface.
+func (t TOMLFormat) From(reader io.Reader) Format {
+	t.reader = reader
+	return t
+}
+
+// Parse implements the Format interface.
+func (t TOMLFormat) Parse() (*tree.Node, error) {
+	if t.reader != nil {
+		dataFromReader, err := io.ReadAll(t.reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReader, err)
+		}
+
+		t.data = append(t.data, dataFromReader...)
+	}
+
+	if t.data == nil {
+		return nil, ErrNoData
+	}
+
+	root, err := newTOMLParser(t.data).parse()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
+	}
+
+	return root, nil
+}
+
+// errTOMLSyntax is wrapped by every error of the TOML parser.
+var errTOMLSyntax = errors.New("invalid TOML")
+
+// tomlDateTimeRE matches the shape of TOML offset and local date-times, dates
+// and times, with two-digit fields; time.Parse is more lenient.
+var tomlDateTimeRE = regexp.MustCompile(
+	`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-](\d{2}):(\d{2}))?)?|\d{2}:\d{2}:\d{2}(\.\d+)?)$`)
+
+// tomlKind tells how a node of the document was defined, which decides
+// whether a later table header or key may add to it.
+type tomlKind int
+
+const (
+	// tomlImplicit is a table created as a parent in a table header.
+	tomlImplicit tomlKind = iota
+	// tomlTable is a table defined by a [table] header.
+	tomlTable
+	// tomlDotted is a table defined by a dotted key.
+	tomlDotted
+	// tomlTableArray is an array of tables defined by [[table]] headers.
+	tomlTableArray
+	// tomlFrozen is a value, a static array or an inline table: it cannot
+	// be extended.
+	tomlFrozen
+)
+
+// tomlParser is a hand-written TOML parser building a tree.Node directly.
+type tomlParser struct {
+	data  []byte
+	pos   int
+	lines lineIndex
+	root  *tree.Node
+	kinds map[*tree.Node]tomlKind
+	// table is the path of the table the key/value pairs belong to.
+	table config.KeyPath
+}
+
+func newTOMLParser(data []byte) *tomlParser {
+	pos := 0
+	if strings.HasPrefix(string(data), "\ufeff") {
+		pos = len("\ufeff")
+	}
+
+	return &tomlParser{
+		data:  data,
+		pos:   pos,
+		lines: newLineIndex(data),
+		root:  tree.New(),
+		kinds: make(map[*tree.Node]tomlKind),
+		table: config.NewKeyPath(""),
+	}
+}
+
+func (p *tomlParser) errorf(offset int, format string, args ...any) error {
+	return p.lines.errorf(errTOMLSyntax, offset, format, args...)
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+// peek returns the current byte, or 0 at the end of the document.
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+func (p *tomlParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(p.data[p.pos:]), prefix)
+}
+
+// skipSpace skips spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to, but not including, the end of line. It
+// stops at a control character other than a tab, which comments must not
+// contain, leaving it to be reported by the caller; a carriage return is
+// allowed only as part of "\r\n".
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+
+	for !p.eof() && !isControl(p.data[p.pos]) {
+		p.pos++
+	}
+}
+
+// skipNewline consumes a single "\n" or "\r\n" and reports whether it did.
+func (p *tomlParser) skipNewline() bool {
+	switch {
+	case p.hasPrefix("\n"):
+		p.pos++
+	case p.hasPrefix("\r\n"):
+		p.pos += len("\r\n")
+	default:
+		return false
+	}
+
+	return true
+}
+
+// skipTrivia skips whitespace, newlines and comments, as allowed inside
+// arrays.
+func (p *tomlParser) skipTrivia() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+
+		if !p.skipNewline() {
+			return
+		}
+	}
+}
+
+// expectLineEnd consumes the rest of a line after a header or a key/value.
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace()
+	p.skipComment()
+
+	if p.eof() || p.skipNewline() {
+		return nil
+	}
+
+	return p.errorf(p.pos, "expected the end of line, found %q", p.peek())
+}
+
+func (p *tomlParser) parse() (*tree.Node, error) {
+	if !utf8.Valid(p.data) {
+		return nil, p.errorf(invalidUTF8Offset(p.data), "invalid UTF-8")
+	}
+
+	for {
+		p.skipSpace()
+		p.skipComment()
+
+		if p.skipNewline() {
+			continue
+		}
+
+		if p.eof() {
+			break
+		}
+
+		var err error
+		if p.peek() == '[' {
+			err = p.parseHeader()
+		} else {
+			err = p.parseKeyValue(p.table)
+		}
+
+		if err == nil {
+			err = p.expectLineEnd()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep empty tables, like YamlFormat keeps empty mappings.
+	for node, kind := range p.kinds {
+		if kind != tomlTableArray && !node.IsArray() && node.Value == nil && len(node.ChildrenKeys()) == 0 {
+			node.Value = map[string]any{}
+		}
+	}
+
+	return p.root, nil
+}
+
+// parseHeader parses a [table] or [[table]] header and makes it current.
+func (p *tomlParser) parseHeader() error {
+	start := p.pos
+	array := p.hasPrefix("[[")
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+
+	p.pos += len(closing)
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+
+	if !p.hasPrefix(closing) {
+		return p.errorf(p.pos, "expected %q to close the table header", closing)
+	}
+
+	p.pos += len(closing)
+
+	path, err := p.openTable(keys, array, start)
+	if err != nil {
+		return err
+	}
+
+	p.table = path
+	p.root.Get(path).Range = p.lines.span(start, p.pos-1)
+
+	return nil
+}
+
+// openTable resolves the keys of a table header into a tree path, creating
+// the tables on the way. For an array of tables a new element is appended.
+func (p *tomlParser) openTable(keys []string, array bool, offset int) (config.KeyPath, error) {
+	path := config.NewKeyPath("")
+
+	for i, key := range keys {
+		parent := p.root.Get(path)
+		child := parent.Child(key)
+		last := i == len(keys)-1
+
+		switch {
+		case child == nil:
+			p.root.Set(path.Append(key), nil)
+			child = parent.Child(key)
+
+			switch {
+			case last && array:
+				child.MarkArray()
+
+				p.kinds[child] = tomlTableArray
+			case last:
+				p.kinds[child] = tomlTable
+			default:
+				p.kinds[child] = tomlImplicit
+			}
+		case last && array:
+			if p.kinds[child] != tomlTableArray {
+				return nil, p.errorf(offset, "key %q is already defined and is not an array of tables", key)
+			}
+		case last:
+			if p.kinds[child] != tomlImplicit {
+				return nil, p.errorf(offset, "table %q is already defined", strings.Join(keys, "."))
+			}
+
+			p.kinds[child] = tomlTable
+		case p.kinds[child] == tomlTableArray:
+			// A header below an array of tables refers to its last element.
+			path = path.Append(key, strconv.Itoa(len(child.ChildrenKeys())-1))
+
+			continue
+		case p.kinds[child] == tomlFrozen:
+			return nil, p.errorf(offset, "key %q is already defined and cannot be extended", key)
+		}
+
+		path = path.Append(key)
+	}
+
+	if array {
+		arrNode := p.root.Get(path)
+		path = path.Append(strconv.Itoa(len(arrNode.ChildrenKeys())))
+
+		p.root.Set(path, nil)
+		p.kinds[p.root.Get(path)] = tomlTable
+	}
+
+	return path, nil
+}
+
+// parseKey parses a possibly dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+
+	for {
+		p.skipSpace()
+
+		key, err := p.parseSimpleKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+
+		p.skipSpace()
+
+		if p.peek() != '.' {
+			return keys, nil
+		}
+
+		p.pos++
+	}
+}
+
+// parseSimpleKey parses a bare or a quoted key.
+func (p *tomlParser) parseSimpleKey() (string, error) {
+	switch p.peek() {
+	case '"':
+		if p.hasPrefix(`"""`) {
+			return "", p.errorf(p.pos, "multi-line strings cannot be keys")
+		}
+
+		return p.parseBasicString()
+	case '\'':
+		if p.hasPrefix("'''") {
+			return "", p.errorf(p.pos, "multi-line strings cannot be keys")
+		}
+
+		return p.parseLiteralString()
+	}
+
+	start := p.pos
+	for !p.eof() && isBareKeyChar(p.data[p.pos]) {
+		p.pos++
+	}
+
+	if start == p.pos {
+		return "", p.errorf(p.pos, "expected a key")
+	}
+
+	return string(p.data[start:p.pos]), nil
+}
+
+func isBareKeyChar(char byte) bool {
+	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || isDigit(char) ||
+		char == '_' || char == '-'
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+// parseKeyValue parses "key = value" and stores the value below table.
+func (p *tomlParser) parseKeyValue(table config.KeyPath) error {
+	start := p.pos
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if p.peek() != '=' {
+		return p.errorf(p.pos, "expected '=' after a key")
+	}
+
+	p.pos++
+	p.skipSpace()
+
+	path, err := p.defineKey(table, keys, start)
+	if err != nil {
+		return err
+	}
+
+	return p.parseValue(path)
+}
+
+// defineKey resolves a dotted key below table into a tree path, creating the
+// intermediate tables. The key itself must not be defined yet.
+func (p *tomlParser) defineKey(table config.KeyPath, keys []string, offset int) (config.KeyPath, error) {
+	path := table
+
+	for _, key := range keys[:len(keys)-1] {
+		parent := p.root.Get(path)
+
+		child := parent.Child(key)
+		if child == nil {
+			p.root.Set(path.Append(key), nil)
+			p.kinds[parent.Child(key)] = tomlDotted
+		} else if kind := p.kinds[child]; kind != tomlDotted && kind != tomlImplicit {
+			return nil, p.errorf(offset, "key %q is already defined and cannot be extended", key)
+		}
+
+		path = path.Append(key)
+	}
+
+	last := keys[len(keys)-1]
+	if p.root.Get(path).Child(last) != nil {
+		return nil, p.errorf(offset, "key %q is already defined", strings.Join(keys, "."))
+	}
+
+	return path.Append(last), nil
+}
+
+// parseValue parses a value and stores it at path.
+func (p *tomlParser) parseValue(path config.KeyPath) error {
+	start := p.pos
+
+	switch p.peek() {
+	case '[':
+		return p.parseArray(path)
+	case '{':
+		return p.parseInlineTable(path)
+	}
+
+	value, err := p.parseScalar()
+	if err != nil {
+		return err
+	}
+
+	p.root.Set(path, value)
+
+	node := p.root.Get(path)
+	node.Range = p.lines.span(start, p.pos-1)
+	p.kinds[node] = tomlFrozen
+
+	return nil
+}
+
+// parseScalar parses a string, a boolean, a number or a date-time.
+func (p *tomlParser) parseScalar() (any, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineBasicString()
+	case p.hasPrefix("'''"):
+		return p.parseMultilineLiteralString()
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	}
+
+	start := p.pos
+	token := p.scanToken()
+
+	switch token {
+	case "":
+		return nil, p.errorf(start, "expected a value")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if strings.Contains(token, ":") || len(token) >= len("2006-01-02") && token[4] == '-' {
+		return p.parseDateTime(token, start)
+	}
+
+	return p.parseNumber(token, start)
+}
+
+// scanToken reads a bare value (boolean, number or date-time). A space is
+// part of the token only as the separator between a date and a time.
+func (p *tomlParser) scanToken() string {
+	start := p.pos
+
+	for !p.eof() {
+		char := p.data[p.pos]
+
+		switch {
+		case isBareKeyChar(char) || char == '+' || char == '.' || char == ':':
+			p.pos++
+		case char == ' ' && p.pos-start == len("2006-01-02") && p.pos+2 < len(p.data) &&
+			isDigit(p.data[p.pos+1]) && isDigit(p.data[p.pos+2]):
+			p.pos++
+		default:
+			return string(p.data[start:p.pos])
+		}
+	}
+
+	return string(p.data[start:p.pos])
+}
+
+// parseDateTime parses an offset date-time into time.Time. Local date-times,
+// dates and times are validated and returned as strings.
+func (p *tomlParser) parseDateTime(token string, offset int) (any, error) {
+	match := tomlDateTimeRE.FindStringSubmatch(token)
+	if match == nil || match[5] != "" && (match[5] > "23" || match[6] > "59") {
+		return nil, p.errorf(offset, "invalid date-time %q", token)
+	}
+
+	normalized := token
+	if len(normalized) > len("2006-01-02") && strings.ContainsRune("tT ", rune(normalized[10])) {
+		normalized = normalized[:10] + "T" + normalized[11:]
+	}
+
+	normalized = strings.Replace(normalized, "z", "Z", 1)
+
+	parsed, err := time.Parse(time.RFC3339Nano, normalized)
+	if err == nil {
+		return parsed, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05.999999999", "2006-01-02", "15:04:05.999999999"} {
+		_, err = time.Parse(layout, normalized)
+		if err == nil {
+			return normalized, nil
+		}
+	}
+
+	return nil, p.errorf(offset, "invalid date-time %q", token)
+}
+
+// parseNumber parses an integer or a float.
+func (p *tomlParser) parseNumber(token string, offset int) (any, error) {
+	if !validUnderscores(token) {
+		return nil, p.errorf(offset, "invalid number %q", token)
+	}
+
+	plain := strings.ReplaceAll(token, "_", "")
+
+	if len(plain) > 2 && plain[0] == '0' && strings.ContainsRune("xob", rune(plain[1])) {
+		value, err := strconv.ParseInt(plain[2:], tomlIntegerBase(plain[1]), 64)
+		if err != nil || strings.ContainsAny(plain[2:], "+-") {
+			return nil, p.errorf(offset, "invalid integer %q", token)
+		}
+
+		return value, nil
+	}
+
+	digits := strings.TrimLeft(plain, "+-")
+	if len(digits) > 1 && digits[0] == '0' && isDigit(digits[1]) {
+		return nil, p.errorf(offset, "leading zeros are not allowed in %q", token)
+	}
+
+	if !strings.ContainsAny(plain, ".eE") {
+		value, err := strconv.ParseInt(plain, 10, 64)
+		if err != nil {
+			return nil, p.errorf(offset, "invalid integer %q", token)
+		}
+
+		return value, nil
+	}
+
+	if !validFloat(digits) {
+		return nil, p.errorf(offset, "invalid float %q", token)
+	}
+
+	value, err := strconv.ParseFloat(plain, 64)
+	if err != nil {
+		return nil, p.errorf(offset, "invalid float %q", token)
+	}
+
+	return value, nil
+}
+
+// tomlIntegerBase returns the base of an integer with a 0x, 0o or 0b
+// prefix.
+func tomlIntegerBase(prefix byte) int {
+	switch prefix {
+	case 'x':
+		return 16 //nolint:mnd
+	case 'o':
+		return 8 //nolint:mnd
+	default:
+		return 2 //nolint:mnd
+	}
+}
+
+// validUnderscores reports whether every underscore in a number is
+// surrounded by digits: decimal ones, or hexadecimal ones after a 0x prefix.
+func validUnderscores(token string) bool {
+	digit := isDigit
+	if strings.HasPrefix(token, "0x") {
+		digit = isHexDigit
+	}
+
+	for i := range len(token) {
+		if token[i] != '_' {
+			continue
+		}
+
+		if i == 0 || i == len(token)-1 || !digit(token[i-1]) || !digit(token[i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHexDigit(char byte) bool {
+	return isDigit(char) || char >= 'a' && char <= 'f' || char >= 'A' && char <= 'F'
+}
+
+// validFloat reports whether an unsigned float has digits on both sides of
+// its decimal point.
+func validFloat(digits string) bool {
+	mantissa, _, _ := strings.Cut(strings.ToLower(digits), "e")
+
+	whole, fraction, hasPoint := strings.Cut(mantissa, ".")
+	if whole == "" || hasPoint && fraction == "" {
+		return false
+	}
+
+	return isDigit(whole[len(whole)-1]) && (!hasPoint || isDigit(fraction[0]))
+}
+
+// parseArray parses a static array into array node at path.
+func (p *tomlParser) parseArray(path config.KeyPath) error {
+	start := p.pos
+	p.pos++
+
+	p.root.Set(path, nil)
+
+	node := p.root.Get(path)
+	node.MarkArray()
+	p.kinds[node] = tomlFrozen
+
+	for i := 0; ; i++ {
+		p.skipTrivia()
+
+		if p.peek() == ']' {
+			break
+		}
+
+		err := p.parseValue(path.Append(strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+
+		p.skipTrivia()
+
+		if p.peek() == ',' {
+			p.pos++
+
+			continue
+		}
+
+		if p.peek() != ']' {
+			return p.errorf(p.pos, "expected ',' or ']' in an array")
+		}
+
+		break
+	}
+
+	p.pos++
+	node.Range = p.lines.span(start, p.pos-1)
+
+	return nil
+}
+
+// parseInlineTable parses an inline table into a mapping node at path.
+func (p *tomlParser) parseInlineTable(path config.KeyPath) error {
+	start := p.pos
+	p.pos++
+
+	p.root.Set(path, nil)
+
+	node := p.root.Get(path)
+	p.kinds[node] = tomlDotted
+
+	p.skipSpace()
+
+	if p.peek() == '}' {
+		p.pos++
+	} else {
+		err := p.parseInlineMembers(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	node.Range = p.lines.span(start, p.pos-1)
+
+	// An inline table is complete: neither headers nor keys may extend it.
+	markFrozen(node, p.kinds)
+
+	return nil
+}
+
+// parseInlineMembers parses the key/value pairs of an inline table up to and
+// including the closing brace.
+func (p *tomlParser) parseInlineMembers(path config.KeyPath) error {
+	for {
+		err := p.parseKeyValue(path)
+		if err != nil {
+			return err
+		}
+
+		p.skipSpace()
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+
+			return nil
+		default:
+			return p.errorf(p.pos, "expected ',' or '}' in an inline table")
+		}
+	}
+}
+
+// markFrozen marks node and everything below it as complete.
+func markFrozen(node *tree.Node, kinds map[*tree.Node]tomlKind) {
+	if node.Value == nil && len(node.ChildrenKeys()) == 0 {
+		node.Value = map[string]any{}
+	}
+
+	kinds[node] = tomlFrozen
+
+	for _, child := range node.Children() {
+		markFrozen(child, kinds)
+	}
+}
+
+// parseBasicString parses a single-line "basic string" with escapes.
+func (p *tomlParser) parseBasicString() (string, error) {
+	var builder strings.Builder
+
+	p.pos++
+
+	for {
+		if p.eof() || p.data[p.pos] == '\n' {
+			return "", p.errorf(p.pos, "unterminated string")
+		}
+
+		switch char := p.data[p.pos]; char {
+		case '"':
+			p.pos++
+
+			return builder.String(), nil
+		case '\\':
+			err := p.parseEscape(&builder)
+			if err != nil {
+				return "", err
+			}
+		default:
+			if isControl(char) {
+				return "", p.errorf(p.pos, "control characters must be escaped")
+			}
+
+			builder.WriteByte(char)
+			p.pos++
+		}
+	}
+}
+
+// parseMultilineBasicString parses a """multi-line basic string""".
+func (p *tomlParser) parseMultilineBasicString() (string, error) {
+	var builder strings.Builder
+
+	p.pos += len(`"""`)
+	p.skipNewline()
+
+	for {
+		switch {
+		case p.eof():
+			return "", p.errorf(p.pos, "unterminated string")
+		case p.hasPrefix(`"""`):
+			return p.closeMultiline(&builder, '"')
+		case p.hasPrefix("\\"):
+			if p.skipLineEndingBackslash() {
+				continue
+			}
+
+			err := p.parseEscape(&builder)
+			if err != nil {
+				return "", err
+			}
+		case p.skipNewline():
+			builder.WriteByte('\n')
+		default:
+			char := p.data[p.pos]
+			if isControl(char) {
+				return "", p.errorf(p.pos, "control characters must be escaped")
+			}
+
+			builder.WriteByte(char)
+			p.pos++
+		}
+	}
+}
+
+// skipLineEndingBackslash skips a backslash at the end of a line together
+// with the whitespace and newlines that follow it.
+func (p *tomlParser) skipLineEndingBackslash() bool {
+	pos := p.pos + 1
+	for pos < len(p.data) && (p.data[pos] == ' ' || p.data[pos] == '\t' || p.data[pos] == '\r') {
+		pos++
+	}
+
+	if pos >= len(p.data) || p.data[pos] != '\n' {
+		return false
+	}
+
+	p.pos = pos
+
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	return true
+}
+
+// closeMultiline ends a multi-line string at a run of quotes: up to two
+// quotes before the closing delimiter belong to the string.
+func (p *tomlParser) closeMultiline(builder *strings.Builder, quote byte) (string, error) {
+	count := 0
+	for p.pos+count < len(p.data) && p.data[p.pos+count] == quote {
+		count++
+	}
+
+	if count > len(`"""`)+2 {
+		return "", p.errorf(p.pos, "too many quotes at the end of a string")
+	}
+
+	for range count - len(`"""`) {
+		builder.WriteByte(quote)
+	}
+
+	p.pos += count
+
+	return builder.String(), nil
+}
+
+// parseEscape parses an escape sequence of a basic string.
+func (p *tomlParser) parseEscape(builder *strings.Builder) error {
+	start := p.pos
+	p.pos++
+
+	if p.eof() {
+		return p.errorf(start, "unterminated escape sequence")
+	}
+
+	escape := p.data[p.pos]
+	p.pos++
+
+	switch escape {
+	case 'b':
+		builder.WriteByte('\b')
+	case 't':
+		builder.WriteByte('\t')
+	case 'n':
+		builder.WriteByte('\n')
+	case 'f':
+		builder.WriteByte('\f')
+	case 'r':
+		builder.WriteByte('\r')
+	case '"', '\\':
+		builder.WriteByte(escape)
+	case 'u', 'U':
+		size := 4
+		if escape == 'U' {
+			size = 8
+		}
+
+		if p.pos+size > len(p.data) {
+			return p.errorf(start, "invalid unicode escape")
+		}
+
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf(start, "invalid unicode escape")
+		}
+
+		builder.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return p.errorf(start, "invalid escape sequence \\%c", escape)
+	}
+
+	return nil
+}
+
+// parseLiteralString parses a single-line 'literal string'.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	start := p.pos
+
+	for !p.eof() && p.data[p.pos] != '\'' {
+		if isControl(p.data[p.pos]) {
+			return "", p.errorf(p.pos, "unterminated string")
+		}
+
+		p.pos++
+	}
+
+	if p.eof() {
+		return "", p.errorf(p.pos, "unterminated string")
+	}
+
+	p.pos++
+
+	return string(p.data[start : p.pos-1]), nil
+}
+
+// parseMultilineLiteralString parses a multi-line literal string, delimited
+// by three single quotes.
+func (p *tomlParser) parseMultilineLiteralString() (string, error) {
+	var builder strings.Builder
+
+	p.pos += len("'''")
+	p.skipNewline()
+
+	for {
+		switch {
+		case p.eof():
+			return "", p.errorf(p.pos, "unterminated string")
+		case p.hasPrefix("'''"):
+			return p.closeMultiline(&builder, '\'')
+		case p.skipNewline():
+			builder.WriteByte('\n')
+		case isControl(p.data[p.pos]):
+			return "", p.errorf(p.pos, "control characters are not allowed in literal strings")
+		default:
+			builder.WriteByte(p.data[p.pos])
+			p.pos++
+		}
+	}
+}
+
+// isControl reports whether char is a control character other than a tab,
+// which TOML strings must not contain literally.
+func isControl(char byte) bool {
+	return char < 0x20 && char != '\t' || char == 0x7f
+}
+
+// invalidUTF8Offset returns the offset of the first invalid UTF-8 sequence
+// in data.
+func invalidUTF8Offset(data []byte) int {
+	offset := 0
+
+	for offset < len(data) {
+		char, size := utf8.DecodeRune(data[offset:])
+		if char == utf8.RuneError && size <= 1 {
+			return offset
+		}
+
+		offset += size
+	}
+
+	return offset
+}
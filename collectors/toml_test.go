@@ -0,0 +1,485 @@
+package collectors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/tree"
+)
+
+const configTOML = `# Sidecar configuration.
+title = "sidecar"
+
+[server]
+host = "localhost" # inline comment
+port = 8_080
+timeout = 1.5
+enabled = true
+started = 1979-05-27T07:32:00Z
+local = 1979-05-27 07:32:00
+day = 1979-05-27
+
+[server.tls]
+cert = '/etc/ssl/cert.pem'
+
+[[replicas]]
+name = "r1"
+peers = [
+  "a", # first
+  "b",
+]
+
+[[replicas]]
+name = "r2"
+limits = { memory = 0x10, cpu.max = 2 }
+
+[[replicas.shards]]
+id = 1
+`
+
+func parseTOML(t *testing.T, data string) *tree.Node {
+	t.Helper()
+
+	root, err := collectors.NewTOMLFormat().From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+	require.NotNil(t, root)
+
+	return root
+}
+
+func TestNewTOMLFormat(t *testing.T) {
+	t.Parallel()
+
+	format := collectors.NewTOMLFormat()
+	require.NotNil(t, format)
+
+	assert.Equal(t, "toml", format.Name())
+	assert.True(t, format.KeepOrder())
+}
+
+func TestTOML_Parse(t *testing.T) {
+	t.Parallel()
+
+	root := parseTOML(t, configTOML)
+
+	assert.Equal(t, []string{"title", "server", "replicas"}, root.ChildrenKeys())
+	assert.Equal(t, []string{"host", "port", "timeout", "enabled", "started", "local", "day", "tls"},
+		root.Get(config.NewKeyPath("server")).ChildrenKeys())
+
+	assert.Equal(t, "sidecar", root.GetValue(config.NewKeyPath("title")))
+	assert.Equal(t, "localhost", root.GetValue(config.NewKeyPath("server/host")))
+	assert.Equal(t, int64(8080), root.GetValue(config.NewKeyPath("server/port")))
+	assert.InDelta(t, 1.5, root.GetValue(config.NewKeyPath("server/timeout")), 0)
+	assert.Equal(t, true, root.GetValue(config.NewKeyPath("server/enabled")))
+	assert.Equal(t, time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+		root.GetValue(config.NewKeyPath("server/started")))
+	assert.Equal(t, "1979-05-27T07:32:00", root.GetValue(config.NewKeyPath("server/local")))
+	assert.Equal(t, "1979-05-27", root.GetValue(config.NewKeyPath("server/day")))
+	assert.Equal(t, "/etc/ssl/cert.pem", root.GetValue(config.NewKeyPath("server/tls/cert")))
+}
+
+func TestTOML_Parse_Arrays(t *testing.T) {
+	t.Parallel()
+
+	root := parseTOML(t, configTOML)
+
+	replicas := root.Get(config.NewKeyPath("replicas"))
+	require.NotNil(t, replicas)
+	assert.True(t, replicas.IsArray())
+	assert.Equal(t, []string{"0", "1"}, replicas.ChildrenKeys())
+
+	peers := root.Get(config.NewKeyPath("replicas/0/peers"))
+	require.NotNil(t, peers)
+	assert.True(t, peers.IsArray())
+	assert.Equal(t, "b", root.GetValue(config.NewKeyPath("replicas/0/peers/1")))
+
+	assert.Equal(t, int64(16), root.GetValue(config.NewKeyPath("replicas/1/limits/memory")))
+	assert.Equal(t, int64(2), root.GetValue(config.NewKeyPath("replicas/1/limits/cpu/max")))
+
+	shards := root.Get(config.NewKeyPath("replicas/1/shards"))
+	require.NotNil(t, shards)
+	assert.True(t, shards.IsArray())
+	assert.Equal(t, int64(1), root.GetValue(config.NewKeyPath("replicas/1/shards/0/id")))
+	assert.Nil(t, root.Get(config.NewKeyPath("replicas/0/shards")))
+}
+
+func TestTOML_Parse_Ranges(t *testing.T) {
+	t.Parallel()
+
+	root := parseTOML(t, configTOML)
+
+	assert.Equal(t, tree.NewRange(2, 9, 2, 17), root.Get(config.NewKeyPath("title")).Range)
+	assert.Equal(t, tree.NewRange(6, 8, 6, 12), root.Get(config.NewKeyPath("server/port")).Range)
+	assert.Equal(t, tree.NewRange(10, 9, 10, 27), root.Get(config.NewKeyPath("server/local")).Range)
+	assert.Equal(t, tree.NewRange(19, 3, 19, 5), root.Get(config.NewKeyPath("replicas/0/peers/0")).Range)
+	assert.Equal(t, tree.NewRange(13, 1, 13, 12), root.Get(config.NewKeyPath("server/tls")).Range)
+}
+
+func TestTOML_Parse_Scalars(t *testing.T) {
+	t.Parallel()
+
+	root := parseTOML(t, `
+hex = 0xDEAD_beef
+oct = 0o755
+bin = 0b1101
+neg = -17
+pos = +42
+exp = 5e+22
+frac = -0.01
+pinf = inf
+ninf = -inf
+nan = nan
+offset = 1979-05-27T00:32:00.999-07:00
+time = 07:32:00
+escapes = "tab\there \"quoted\" \u00e9 \U0001F600"
+multi = """
+Roses are red
+Violets are \
+    blue"""
+literal = '''
+C:\Users\nodejs\templates'''
+quotes = """two "" quotes"""""
+"quoted key" = 1
+site."google.com" = true
+empty = {}
+none = []
+`)
+
+	assert.Equal(t, int64(0xdeadbeef), root.GetValue(config.NewKeyPath("hex")))
+	assert.Equal(t, int64(0o755), root.GetValue(config.NewKeyPath("oct")))
+	assert.Equal(t, int64(13), root.GetValue(config.NewKeyPath("bin")))
+	assert.Equal(t, int64(-17), root.GetValue(config.NewKeyPath("neg")))
+	assert.Equal(t, int64(42), root.GetValue(config.NewKeyPath("pos")))
+	assert.InDelta(t, 5e+22, root.GetValue(config.NewKeyPath("exp")), 0)
+	assert.InDelta(t, -0.01, root.GetValue(config.NewKeyPath("frac")), 0)
+	assert.Equal(t, math.Inf(1), root.GetValue(config.NewKeyPath("pinf")))
+	assert.Equal(t, math.Inf(-1), root.GetValue(config.NewKeyPath("ninf")))
+
+	nan, ok := root.GetValue(config.NewKeyPath("nan")).(float64)
+	require.True(t, ok)
+	assert.True(t, math.IsNaN(nan))
+
+	offset, ok := root.GetValue(config.NewKeyPath("offset")).(time.Time)
+	require.True(t, ok)
+	assert.True(t, offset.Equal(time.Date(1979, 5, 27, 7, 32, 0, 999000000, time.UTC)))
+
+	assert.Equal(t, "07:32:00", root.GetValue(config.NewKeyPath("time")))
+	assert.Equal(t, "tab\there \"quoted\" \u00e9 \U0001F600", root.GetValue(config.NewKeyPath("escapes")))
+	assert.Equal(t, "Roses are red\nViolets are blue", root.GetValue(config.NewKeyPath("multi")))
+	assert.Equal(t, `C:\Users\nodejs\templates`, root.GetValue(config.NewKeyPath("literal")))
+	assert.Equal(t, `two "" quotes""`, root.GetValue(config.NewKeyPath("quotes")))
+	assert.Equal(t, int64(1), root.GetValue(config.NewKeyPath("quoted key")))
+	assert.Equal(t, true, root.Get(config.NewKeyPath("site")).Child("google.com").Value)
+	assert.Equal(t, map[string]any{}, root.GetValue(config.NewKeyPath("empty")))
+	assert.True(t, root.Get(config.NewKeyPath("none")).IsArray())
+}
+
+func TestTOML_Parse_EmptyTable(t *testing.T) {
+	t.Parallel()
+
+	root := parseTOML(t, "[a]\n[b.c]\n")
+
+	assert.Equal(t, map[string]any{}, root.GetValue(config.NewKeyPath("a")))
+	assert.Equal(t, map[string]any{}, root.GetValue(config.NewKeyPath("b/c")))
+}
+
+func TestTOML_Parse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewTOMLFormat().From(nil).Parse()
+	require.Nil(t, root)
+	require.ErrorIs(t, err, collectors.ErrNoData)
+
+	for data, message := range map[string]string{
+		"a = 1\na = 2":                     "line 2, column 1: key \"a\" is already defined",
+		"[a]\nb = 1\n[a]":                  "line 3, column 1: table \"a\" is already defined",
+		"a = {b = 1}\n[a.c]":               "key \"a\" is already defined and cannot be extended",
+		"a = [1]\n[[a]]":                   "is not an array of tables",
+		"[a]\nb.c = 1\n[a.b]":              "table \"a.b\" is already defined",
+		"a = 1 b = 2":                      "line 1, column 7: expected the end of line",
+		"a = \"unterminated":               "unterminated string",
+		"a = 012":                          "leading zeros",
+		"a = 1__0":                         "invalid number",
+		"a = 3.":                           "invalid float",
+		"a = 9223372036854775808":          "invalid integer",
+		"a = \"\\q\"":                      "invalid escape",
+		"a = [1, 2":                        "expected ',' or ']'",
+		"a = {b = 1,}":                     "expected a key",
+		"a = 1979-13-01":                   "invalid date-time",
+		"a =":                              "expected a value",
+		"= 1":                              "expected a key",
+		"[a":                               "expected \"]\"",
+		"a = \"\"\"x":                      "unterminated string",
+		"a = {b = 1}\na.c = 2":             "cannot be extended",
+		"[[a]]\n[a]":                       "table \"a\" is already defined",
+		"x = 1\n[x.y]":                     "key \"x\" is already defined and cannot be extended",
+		"a = 'literal\nnext'":              "unterminated string",
+		"a = \"\"\"x\"\"\"\"\"\"":          "too many quotes",
+		"a.b = 1\na = 2":                   "key \"a\" is already defined",
+		"a = 1979-05-27T07:32:00Zgarbage":  "invalid date-time",
+		"a = 0x":                           "invalid",
+		"a = -0x10":                        "invalid",
+		"a = truee":                        "invalid float",
+		"a = [1,,2]":                       "expected a value",
+		"[a.b]\n[a]\nb = 1":                "key \"b\" is already defined",
+		"a = \"\\u00\"":                    "invalid unicode escape",
+		"a = 1e":                           "invalid float",
+		"a = .5":                           "invalid float",
+		"a = 1.e5":                         "invalid float",
+		"a = 1_":                           "invalid number",
+		"a = _1":                           "invalid number",
+		"a = 0b102":                        "invalid integer",
+		"a = {b = 1\n}":                    "expected ',' or '}'",
+		"a = \"\\uD800\"":                  "invalid unicode escape",
+		"a = 07:32":                        "invalid date-time",
+		"a = 1979-05-27T07:32:00+25:00":    "invalid date-time",
+		"a = 1979-05-27 07:32:00 07:32:00": "expected the end of line",
+	} {
+		root, err = collectors.NewTOMLFormat().From(strings.NewReader(data)).Parse()
+		require.Nil(t, root, data)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, data)
+		assert.Contains(t, err.Error(), message, data)
+	}
+}
+
+func TestTOML_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "sidecar.toml", "[sidecar]\nport = 9000\n")
+	writeTestFile(t, dir, "ignored.yaml", "port: 1")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewDirectory(dir, ".toml", collectors.NewTOMLFormat()))
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	assert.Equal(t, 9000, config.MustGet[int](&cfg, config.NewKeyPath("sidecar/port")))
+
+	_, ok := cfg.Lookup(config.NewKeyPath("port"))
+	assert.False(t, ok)
+}
+
+// tomlTestDir holds the TOML 1.0.0 cases of the toml-test suite
+// (https://github.com/toml-lang/toml-test), as bundled with
+// github.com/BurntSushi/toml v1.6.0. Cases of TOML 1.1.0 features are left
+// out.
+const tomlTestDir = "testdata/toml-test"
+
+// tomlTestSkip lists the cases that deliberately differ from the suite.
+var tomlTestSkip = map[string]string{
+	"valid/empty-nothing.toml": "an empty document is ErrNoData, as for every Format",
+}
+
+// tomlTestCases returns the paths of the suite's TOML files under dir,
+// relative to tomlTestDir.
+func tomlTestCases(t testing.TB, dir string) []string {
+	t.Helper()
+
+	var cases []string
+
+	err := filepath.WalkDir(filepath.Join(tomlTestDir, dir), func(path string, _ fs.DirEntry, err error) error {
+		if err != nil || filepath.Ext(path) != ".toml" {
+			return err
+		}
+
+		rel, err := filepath.Rel(tomlTestDir, path)
+		cases = append(cases, filepath.ToSlash(rel))
+
+		return err
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+
+	return cases
+}
+
+func TestTOML_Conformance_Valid(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range tomlTestCases(t, "valid") {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if reason, ok := tomlTestSkip[name]; ok {
+				t.Skip(reason)
+			}
+
+			data, err := os.ReadFile(filepath.Join(tomlTestDir, name))
+			require.NoError(t, err)
+
+			expectedJSON, err := os.ReadFile(filepath.Join(tomlTestDir, strings.TrimSuffix(name, ".toml")+".json"))
+			require.NoError(t, err)
+
+			var expected any
+			require.NoError(t, json.Unmarshal(expectedJSON, &expected))
+
+			root, err := collectors.NewTOMLFormat().From(bytes.NewReader(data)).Parse()
+			require.NoError(t, err)
+			require.NoError(t, compareTOMLTest("", expected, root))
+		})
+	}
+}
+
+func TestTOML_Conformance_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range tomlTestCases(t, "invalid") {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(filepath.Join(tomlTestDir, name))
+			require.NoError(t, err)
+
+			_, err = collectors.NewTOMLFormat().From(bytes.NewReader(data)).Parse()
+			require.Error(t, err)
+		})
+	}
+}
+
+func FuzzTOML(f *testing.F) {
+	f.Add([]byte(configTOML))
+
+	for _, name := range append(tomlTestCases(f, "valid"), tomlTestCases(f, "invalid")...) {
+		data, err := os.ReadFile(filepath.Join(tomlTestDir, name))
+		require.NoError(f, err)
+
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		root, err := collectors.NewTOMLFormat().From(bytes.NewReader(data)).Parse()
+		if err != nil {
+			require.Nil(t, root)
+
+			if !errors.Is(err, collectors.ErrNoData) {
+				require.ErrorIs(t, err, collectors.ErrUnmarshall)
+			}
+
+			return
+		}
+
+		require.NotNil(t, root)
+	})
+}
+
+// errTOMLTest is returned for a parsed document that differs from the
+// expected toml-test JSON.
+var errTOMLTest = errors.New("toml-test mismatch")
+
+// compareTOMLTest compares a parsed node with its toml-test JSON encoding:
+// tables are objects, arrays are arrays and values are {"type", "value"}
+// objects.
+func compareTOMLTest(path string, expected any, node *tree.Node) error {
+	switch expected := expected.(type) {
+	case []any:
+		children := node.Children()
+		if !node.IsArray() || len(children) != len(expected) {
+			return fmt.Errorf("%w: %s: expected an array of %d values", errTOMLTest, path, len(expected))
+		}
+
+		for i, child := range children {
+			err := compareTOMLTest(path+"["+strconv.Itoa(i)+"]", expected[i], child)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case map[string]any:
+		typ, isType := expected["type"].(string)
+		value, isValue := expected["value"].(string)
+
+		if isType && isValue && len(expected) == 2 {
+			return compareTOMLTestValue(path, typ, value, node.Value)
+		}
+
+		if empty, ok := node.Value.(map[string]any); ok && len(empty) == 0 && len(expected) == 0 {
+			return nil
+		}
+
+		if node.IsArray() || node.Value != nil || len(node.ChildrenKeys()) != len(expected) {
+			return fmt.Errorf("%w: %s: expected a table of %d keys", errTOMLTest, path, len(expected))
+		}
+
+		for key, value := range expected {
+			child := node.Child(key)
+			if child == nil {
+				return fmt.Errorf("%w: %s: missing key %q", errTOMLTest, path, key)
+			}
+
+			err := compareTOMLTest(path+"."+key, value, child)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %s: unexpected %T in the expected JSON", errTOMLTest, path, expected)
+	}
+}
+
+// compareTOMLTestValue compares a parsed value with a toml-test typed value.
+// Local date-times, dates and times are kept as strings by TOMLFormat.
+func compareTOMLTestValue(path, typ, expected string, actual any) error {
+	var equal bool
+
+	switch typ {
+	case "string":
+		equal = actual == expected
+	case "integer":
+		value, err := strconv.ParseInt(expected, 10, 64)
+		equal = err == nil && actual == value
+	case "float":
+		value, err := strconv.ParseFloat(expected, 64)
+		got, ok := actual.(float64)
+		equal = err == nil && ok && (got == value || math.IsNaN(got) && math.IsNaN(value))
+	case "bool":
+		equal = actual == (expected == "true")
+	case "datetime":
+		value, err := time.Parse(time.RFC3339Nano, expected)
+		got, ok := actual.(time.Time)
+		equal = err == nil && ok && got.Equal(value)
+	default:
+		got, ok := actual.(string)
+		equal = ok && normalizeLocalTime(got) == normalizeLocalTime(expected)
+	}
+
+	if !equal {
+		return fmt.Errorf("%w: %s: expected %s %q, got %#v", errTOMLTest, path, typ, expected, actual)
+	}
+
+	return nil
+}
+
+// normalizeLocalTime normalizes the date/time separator and the trailing
+// zeros of fractional seconds of a local date-time, date or time.
+func normalizeLocalTime(value string) string {
+	if len(value) > len("2006-01-02") && value[10] == ' ' {
+		value = value[:10] + "T" + value[11:]
+	}
+
+	if strings.Contains(value, ".") {
+		value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	}
+
+	return value
+}
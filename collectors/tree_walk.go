@@ -35,9 +35,13 @@ func flattenMapIntoTree(node *tree.Node, prefix config.KeyPath, m map[string]any
 	}
 }
 
-// setSource recursively sets the Source field on a tree node and all its descendants.
+// setSource recursively sets the Source field on a tree node and all its
+// descendants. Nodes that already have a source, such as those of included
+// files, keep it.
 func setSource(node *tree.Node, source string) {
-	node.Source = source
+	if node.Source == "" {
+		node.Source = source
+	}
 
 	for _, key := range node.ChildrenKeys() {
 		child := node.Child(key)
@@ -2,19 +2,130 @@ package collectors
 
 import (
 	"context"
+	"slices"
+	"time"
+
+	"github.com/tarantool/go-config"
 )
 
 // WatchEvent represents a change notification from storage.
-type WatchEvent struct {
-	// Prefix indicates the key or prefix that was changed.
-	Prefix string
-}
+// It is an alias of [config.WatchEvent].
+type WatchEvent = config.WatchEvent
 
 // Watcher provides reactive change notifications from a storage backend.
 // Collectors that support watching for changes implement this interface
-// in addition to the standard Collector interface.
-type Watcher interface {
-	// Watch returns a channel that streams change events for the collector's
-	// key or prefix. The channel is closed when the context is cancelled.
-	Watch(ctx context.Context) (<-chan WatchEvent, error)
+// in addition to the standard Collector interface. It is an alias of
+// [config.Watcher], so a [config.Reloader] picks such collectors up
+// automatically.
+type Watcher = config.Watcher
+
+// CoalesceWatchEvents merges bursts of events of a single watcher: the first
+// event opens a window of the given length, and the events received within
+// it are sent as one event when it closes. The merged event lists every
+// changed key once with its last change, so a key put and then deleted is
+// reported as deleted, and carries the last known revision. If any of the
+// events lists no keys, the merged event lists none either, as anything may
+// have changed.
+//
+// A non-positive window returns events unchanged. Otherwise the returned
+// channel is closed when ctx is done or events is closed, after the pending
+// event, if any, has been sent.
+func CoalesceWatchEvents(ctx context.Context, events <-chan WatchEvent, window time.Duration) <-chan WatchEvent {
+	if window <= 0 {
+		return events
+	}
+
+	eventCh := make(chan WatchEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		for {
+			var event WatchEvent
+
+			select {
+			case <-ctx.Done():
+				return
+			case received, ok := <-events:
+				if !ok {
+					return
+				}
+
+				event = received
+			}
+
+			event, open := collectWatchEvents(ctx, events, event, window, mergeWatchEvents)
+
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+
+			if !open {
+				return
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+// collectWatchEvents merges into event the events received within window
+// with merge. It returns false if events has been closed.
+func collectWatchEvents[E any](
+	ctx context.Context,
+	events <-chan E,
+	event E,
+	window time.Duration,
+	merge func(earlier, later E) E,
+) (E, bool) {
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return event, true
+		case <-timer.C:
+			return event, true
+		case received, ok := <-events:
+			if !ok {
+				return event, false
+			}
+
+			event = merge(event, received)
+		}
+	}
+}
+
+// mergeWatchEvents merges a later event into an earlier one.
+func mergeWatchEvents(earlier, later WatchEvent) WatchEvent {
+	merged := WatchEvent{Prefix: earlier.Prefix, Keys: nil, Revision: later.Revision}
+	if merged.Revision == "" {
+		merged.Revision = earlier.Revision
+	}
+
+	if len(earlier.Keys) == 0 || len(later.Keys) == 0 {
+		return merged
+	}
+
+	merged.Keys = slices.Clone(earlier.Keys)
+
+	index := make(map[string]int, len(merged.Keys))
+	for i, key := range merged.Keys {
+		index[key.Key] = i
+	}
+
+	for _, key := range later.Keys {
+		if i, ok := index[key.Key]; ok {
+			merged.Keys[i] = key
+			continue
+		}
+
+		index[key.Key] = len(merged.Keys)
+		merged.Keys = append(merged.Keys, key)
+	}
+
+	return merged
 }
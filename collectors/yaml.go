@@ -1,9 +1,12 @@
 package collectors
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -12,22 +15,67 @@ import (
 	"go.yaml.in/yaml/v3"
 )
 
+// YamlTagHandler resolves a node carrying a custom YAML tag (e.g. "!env") to
+// its value. The value is stored as a leaf, except for a *yaml.Node, which is
+// flattened in place of the tagged node.
+type YamlTagHandler func(node *yaml.Node) (any, error)
+
+// YamlOption configures a YamlFormat.
+type YamlOption func(*YamlFormat)
+
+// WithYamlTag registers a handler for nodes tagged with tag, such as "!env",
+// "!file" or "!secret". Tags are matched exactly, including the leading '!'.
+// When the configuration is written back as YAML, a tagged value that was not
+// modified keeps its original tagged form, so resolved secrets do not leak.
+func WithYamlTag(tag string, handler YamlTagHandler) YamlOption {
+	return func(y *YamlFormat) {
+		if handler == nil {
+			return
+		}
+
+		if y.tags == nil {
+			y.tags = make(map[string]YamlTagHandler)
+		}
+
+		y.tags[tag] = handler
+	}
+}
+
 // YamlFormat implements Format interface.
+//
+// Aliases are followed and YAML 1.1 merge keys ("<<: *base") are applied:
+// the keys of the merged mappings are added unless the mapping sets them
+// itself, with earlier mappings of a merged sequence taking precedence.
+// Parse reads the first document of a stream; ParseDocuments reads all of
+// them (see [MultiDocumentFormat]). File includes are enabled with
+// [WithYamlIncludes].
 type YamlFormat struct {
 	name      string
 	keepOrder bool
 	data      []byte
 	reader    io.Reader
+	tags      map[string]YamlTagHandler
+	includes  *string
 }
 
 // NewYamlFormat return new YamlFormat object.
-func NewYamlFormat() Format {
-	return YamlFormat{
+func NewYamlFormat(opts ...YamlOption) Format {
+	format := YamlFormat{
 		name:      "yaml",
 		keepOrder: true,
 		data:      nil,
 		reader:    nil,
+		tags:      nil,
+		includes:  nil,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&format)
+		}
 	}
+
+	return format
 }
 
 // Name implements the Format interface.
@@ -48,10 +96,56 @@ func (y YamlFormat) From(reader io.Reader) Format {
 
 // Parse implements the Format interface.
 func (y YamlFormat) Parse() (*tree.Node, error) {
-	var err error
-
 	var node yaml.Node
 
+	data, err := y.read()
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
+	}
+
+	return y.toTree(&node, readerName(y.reader))
+}
+
+// ParseDocuments implements the MultiDocumentFormat interface. Empty
+// documents are skipped.
+func (y YamlFormat) ParseDocuments() ([]*tree.Node, error) {
+	data, err := y.read()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var roots []*tree.Node
+
+	for {
+		var node yaml.Node
+
+		err = decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return roots, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: document %d: %w", ErrUnmarshall, len(roots)+1, err)
+		}
+
+		root, err := y.toTree(&node, readerName(y.reader))
+		if err != nil {
+			return nil, err
+		}
+
+		if !root.IsLeaf() || root.Value != nil {
+			roots = append(roots, root)
+		}
+	}
+}
+
+// read returns the data to parse, appending the data of the reader.
+func (y YamlFormat) read() ([]byte, error) {
 	if y.reader != nil {
 		dataFromReader, err := io.ReadAll(y.reader)
 		if err != nil {
@@ -65,94 +159,322 @@ func (y YamlFormat) Parse() (*tree.Node, error) {
 		return nil, ErrNoData
 	}
 
-	err = yaml.Unmarshal(y.data, &node)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
-	}
+	return y.data, nil
+}
 
+// toTree converts a decoded YAML document, read from the file name (if
+// known), into a tree.
+func (y YamlFormat) toTree(node *yaml.Node, name string) (*tree.Node, error) {
 	root := tree.New()
 
-	flattenYamlIntoTree(root, nil, node, config.NewKeyPath(""))
+	flattener := yamlFlattener{
+		tags:      y.tags,
+		includes:  nil,
+		dir:       "",
+		source:    "",
+		expanding: make(map[*yaml.Node]bool),
+	}
+	if y.includes != nil {
+		flattener.includes = newYamlIncludes(name)
+		flattener.dir = *y.includes
+
+		if name != "" {
+			flattener.dir = filepath.Dir(name)
+		}
+	}
+
+	err := flattener.flatten(root, nil, *node, config.NewKeyPath(""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshall, err)
+	}
 
 	return root, nil
 }
 
-func flattenYamlIntoTree(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
+// yamlFlattener stores YAML nodes into a tree.
+type yamlFlattener struct {
+	tags map[string]YamlTagHandler
+	// includes is nil unless file includes are enabled.
+	includes *yamlIncludes
+	// dir is the directory relative include paths are resolved against.
+	dir string
+	// source is the path of the included file being flattened, empty for
+	// the parsed document itself.
+	source string
+	// expanding holds the anchored nodes being expanded, to detect aliases
+	// that refer to a node containing them.
+	expanding map[*yaml.Node]bool
+}
+
+func (f yamlFlattener) flatten(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
 	prefix config.KeyPath,
-) {
+) error {
+	if f.includes != nil && yamlNode.Tag == yamlIncludeTag {
+		return f.flattenInclude(node, key, yamlNode, prefix)
+	}
+
+	if handler, ok := f.tags[yamlNode.Tag]; ok && yamlNode.Kind != yaml.AliasNode {
+		return f.flattenTagged(node, key, yamlNode, prefix, handler)
+	}
+
 	switch yamlNode.Kind {
 	case yaml.DocumentNode:
 		for _, child := range yamlNode.Content {
-			flattenYamlIntoTree(node, nil, *child, prefix)
+			err := f.flattenDocument(node, key, *child, prefix)
+			if err != nil {
+				return err
+			}
 		}
 	case yaml.MappingNode:
-		if len(yamlNode.Content) == 0 {
-			node.Set(prefix, map[string]any{})
+		return f.flattenMapping(node, key, yamlNode, prefix)
+	case yaml.SequenceNode:
+		// Ensure the array node exists even for empty sequences.
+		if node.Get(prefix) == nil {
+			node.Set(prefix, nil)
+		}
 
-			if target := node.Get(prefix); target != nil {
-				yamlNodeCopy := yamlNode
-				target.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
-			}
+		arrNode := node.Get(prefix)
+		replaceYamlTarget(arrNode, false)
+		arrNode.MarkArray()
 
-			return
+		arrNode.Source = f.source
+		yamlNodeCopy := yamlNode
+		arrNode.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
+
+		for i, item := range yamlNode.Content {
+			newPrefix := prefix.Append(strconv.Itoa(i))
+
+			err := f.flatten(node, nil, *item, newPrefix)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+		// Field `Value` contains name of the anchor.
+		// Field `Alias` contains pointer to the anchor.
+		target := yamlNode.Alias
+		if f.expanding[target] {
+			return fmt.Errorf("line %d, column %d: %w", yamlNode.Line, yamlNode.Column, errYamlRecursiveAlias)
 		}
 
-		// Ensure the mapping node exists so we can attach the annotation.
-		if len(prefix) > 0 && node.Get(prefix) == nil {
-			node.Set(prefix, nil)
+		f.expanding[target] = true
+		defer delete(f.expanding, target)
+
+		return f.flatten(node, key, *target, prefix)
+	case yaml.ScalarNode:
+		f.setScalar(node, key, yamlNode, prefix, resolveYamlScalar(yamlNode))
+	default:
+	}
+
+	return nil
+}
+
+func (f yamlFlattener) flattenMapping(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
+	prefix config.KeyPath,
+) error {
+	pairs, err := yamlMappingPairs(&yamlNode, f.expanding)
+	if err != nil {
+		return err
+	}
 
-			node.Get(prefix).Value = nil
+	if len(pairs) == 0 {
+		if target := node.Get(prefix); target != nil {
+			replaceYamlTarget(target, false)
 		}
 
+		node.Set(prefix, map[string]any{})
+
 		if target := node.Get(prefix); target != nil {
+			target.Source = f.source
 			yamlNodeCopy := yamlNode
 			target.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
 		}
 
-		for i := 0; i < len(yamlNode.Content); i += 2 {
-			k := yamlNode.Content[i]
-			value := yamlNode.Content[i+1]
+		return nil
+	}
+
+	// Ensure the mapping node exists so we can attach the annotation.
+	if len(prefix) > 0 && node.Get(prefix) == nil {
+		node.Set(prefix, nil)
+
+		node.Get(prefix).Value = nil
+	}
+
+	if target := node.Get(prefix); target != nil {
+		replaceYamlTarget(target, true)
 
-			newPrefix := prefix.Append(k.Value)
+		target.Source = f.source
+		yamlNodeCopy := yamlNode
+		target.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
+	}
 
-			flattenYamlIntoTree(node, k, *value, newPrefix)
+	for _, pair := range pairs {
+		err = f.flatten(node, pair.key, *pair.value, prefix.Append(pair.key.Value))
+		if err != nil {
+			return err
 		}
-	case yaml.SequenceNode:
-		// Ensure the array node exists even for empty sequences.
-		if node.Get(prefix) == nil {
-			node.Set(prefix, nil)
+	}
+
+	return nil
+}
+
+// flattenTagged stores the value a tag handler resolves yamlNode to.
+func (f yamlFlattener) flattenTagged(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
+	prefix config.KeyPath, handler YamlTagHandler,
+) error {
+	value, err := handler(&yamlNode)
+	if err != nil {
+		return fmt.Errorf("line %d, column %d: tag %s: %w", yamlNode.Line, yamlNode.Column, yamlNode.Tag, err)
+	}
+
+	if resolved, ok := value.(*yaml.Node); ok {
+		if resolved.Tag == yamlNode.Tag {
+			// Do not resolve the same tag again.
+			plain := f
+			plain.tags = nil
+
+			return plain.flatten(node, key, *resolved, prefix)
 		}
 
-		arrNode := node.Get(prefix)
-		arrNode.MarkArray()
+		return f.flatten(node, key, *resolved, prefix)
+	}
+
+	f.setScalar(node, key, yamlNode, prefix, value)
+
+	return nil
+}
+
+// setScalar stores a leaf value with the position and annotation of yamlNode.
+func (f yamlFlattener) setScalar(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
+	prefix config.KeyPath, value any,
+) {
+	if target := node.Get(prefix); target != nil {
+		replaceYamlTarget(target, false)
+	}
+
+	node.Set(prefix, value)
+
+	target := node.Get(prefix)
+	if target != nil {
+		target.Source = f.source
+		target.Range = tree.Range{
+			Start: tree.Position{Line: yamlNode.Line, Column: yamlNode.Column},
+			End:   tree.Position{Line: yamlNode.Line, Column: yamlNode.Column},
+		}
 
 		yamlNodeCopy := yamlNode
-		arrNode.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
+		target.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
+	}
+}
 
-		for i, item := range yamlNode.Content {
-			newPrefix := prefix.Append(strconv.Itoa(i))
+// yamlPair is a key/value pair of a YAML mapping.
+type yamlPair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// yamlMappingPairs returns the key/value pairs of a mapping with merge keys
+// applied: the pairs of merged mappings are placed where the merge key is,
+// unless the mapping sets the key itself or an earlier merged mapping did.
+// expanding holds the mappings being merged, which may not be merged again.
+func yamlMappingPairs(mapping *yaml.Node, expanding map[*yaml.Node]bool) ([]yamlPair, error) {
+	explicit := make(map[string]bool, len(mapping.Content)/2) //nolint:mnd
 
-			flattenYamlIntoTree(node, nil, *item, newPrefix)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !isYamlMergeKey(mapping.Content[i]) {
+			explicit[mapping.Content[i].Value] = true
+		}
+	}
+
+	pairs := make([]yamlPair, 0, len(mapping.Content)/2) //nolint:mnd
+	merged := make(map[string]bool)
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+
+		if !isYamlMergeKey(key) {
+			pairs = append(pairs, yamlPair{key: key, value: value})
+			continue
 		}
-	case yaml.AliasNode:
-		// Field `Value` contains name of the anchor.
-		// Field `Alias` contains pointer to the anchor.
-		flattenYamlIntoTree(node, key, *yamlNode.Alias, prefix)
-	case yaml.ScalarNode:
-		node.Set(prefix, resolveYamlScalar(yamlNode))
 
-		target := node.Get(prefix)
-		if target != nil {
-			target.Range = tree.Range{
-				Start: tree.Position{Line: yamlNode.Line, Column: yamlNode.Column},
-				End:   tree.Position{Line: yamlNode.Line, Column: yamlNode.Column},
+		sources, err := yamlMergeSources(value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, source := range sources {
+			if expanding[source] {
+				return nil, fmt.Errorf("line %d, column %d: %w", value.Line, value.Column, errYamlRecursiveAlias)
 			}
 
-			yamlNodeCopy := yamlNode
-			target.SetAnnotation(config.YAMLAnnotation{Key: key, Val: &yamlNodeCopy})
+			expanding[source] = true
+			sourcePairs, err := yamlMappingPairs(source, expanding)
+
+			delete(expanding, source)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, pair := range sourcePairs {
+				if explicit[pair.key.Value] || merged[pair.key.Value] {
+					continue
+				}
+
+				merged[pair.key.Value] = true
+				pairs = append(pairs, pair)
+			}
 		}
+	}
+
+	return pairs, nil
+}
+
+// isYamlMergeKey reports whether key is the "<<" merge key.
+func isYamlMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge"
+}
+
+// yamlMergeSources returns the mappings merged by the value of a merge key:
+// a mapping or a sequence of mappings, possibly given by aliases.
+func yamlMergeSources(value *yaml.Node) ([]*yaml.Node, error) {
+	value = resolveYamlAlias(value)
+
+	switch value.Kind {
+	case yaml.MappingNode:
+		return []*yaml.Node{value}, nil
+	case yaml.SequenceNode:
+		sources := make([]*yaml.Node, 0, len(value.Content))
+
+		for _, item := range value.Content {
+			item = resolveYamlAlias(item)
+			if item.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d, column %d: %w", item.Line, item.Column, errYamlMerge)
+			}
+
+			sources = append(sources, item)
+		}
+
+		return sources, nil
 	default:
+		return nil, fmt.Errorf("line %d, column %d: %w", value.Line, value.Column, errYamlMerge)
+	}
+}
+
+// errYamlMerge is returned for a merge key whose value is not a mapping.
+var errYamlMerge = errors.New("merge key value must be a mapping or a sequence of mappings")
+
+// errYamlRecursiveAlias is returned for an alias or a merge key that refers to
+// a node containing it.
+var errYamlRecursiveAlias = errors.New("recursive alias")
+
+// resolveYamlAlias follows aliases to the anchored node.
+func resolveYamlAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
 	}
+
+	return node
 }
 
 // resolveYamlScalar converts a YAML scalar node's string value into a typed Go value
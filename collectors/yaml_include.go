@@ -0,0 +1,201 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/tree"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// yamlIncludeTag marks a node replaced by the content of a file.
+	yamlIncludeTag = "!include"
+	// yamlIncludeKey is the top-level key listing files to merge into a
+	// document.
+	yamlIncludeKey = "include"
+)
+
+// WithYamlIncludes enables file includes. A node tagged "!include path.yaml"
+// is replaced by the content of that file, and the files listed by a
+// top-level "include" key (a path or a list of paths) are merged into the
+// document in order, under its own keys, which take precedence.
+//
+// Relative paths are resolved against the directory of the including file.
+// The file name of the parsed document is taken from its reader (such as the
+// *os.File of [File], or the files of [Directory]); when it is unknown, paths
+// are resolved against dir. Included files may include other files. Every
+// included value keeps its own file in tree.Node.Source and its own Range; a
+// custom [config.Merger] sets the source of the values it writes itself.
+//
+// Includes read arbitrary local files, so enable them only for trusted
+// documents. Included files are always read from the local filesystem, also
+// for documents of [NewFileFS] and [NewDirectoryFS].
+func WithYamlIncludes(dir string) YamlOption {
+	return func(y *YamlFormat) {
+		y.includes = &dir
+	}
+}
+
+// errYamlIncludeValue is returned for an include that is not a path.
+var errYamlIncludeValue = errors.New("include must be a path or a list of paths")
+
+// yamlIncludes tracks the chain of files being included, to detect cycles.
+type yamlIncludes struct {
+	stack []string
+}
+
+// newYamlIncludes starts an include chain at the file name, if known.
+func newYamlIncludes(name string) *yamlIncludes {
+	includes := &yamlIncludes{stack: nil}
+
+	if name != "" {
+		if abs, err := filepath.Abs(name); err == nil {
+			includes.stack = append(includes.stack, abs)
+		}
+	}
+
+	return includes
+}
+
+// flattenDocument flattens the content of a document, merging the files of
+// its top-level include key first.
+func (f yamlFlattener) flattenDocument(node *tree.Node, key *yaml.Node, content yaml.Node,
+	prefix config.KeyPath,
+) error {
+	if f.includes == nil || content.Kind != yaml.MappingNode {
+		return f.flatten(node, key, content, prefix)
+	}
+
+	rest := content
+	rest.Content = nil
+
+	for i := 0; i+1 < len(content.Content); i += 2 {
+		name, value := content.Content[i], content.Content[i+1]
+		if name.Kind != yaml.ScalarNode || name.Value != yamlIncludeKey || isYamlMergeKey(name) {
+			rest.Content = append(rest.Content, name, value)
+			continue
+		}
+
+		paths, err := yamlIncludePaths(resolveYamlAlias(value))
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			err = f.includeFile(node, key, path.Value, path, prefix)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(rest.Content) == 0 && len(content.Content) > 0 {
+		return nil
+	}
+
+	return f.flatten(node, key, rest, prefix)
+}
+
+// yamlIncludePaths returns the path nodes of an include key: a scalar or a
+// sequence of scalars.
+func yamlIncludePaths(value *yaml.Node) ([]*yaml.Node, error) {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return []*yaml.Node{value}, nil
+	case yaml.SequenceNode:
+		paths := make([]*yaml.Node, 0, len(value.Content))
+
+		for _, item := range value.Content {
+			item = resolveYamlAlias(item)
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d, column %d: %w", item.Line, item.Column, errYamlIncludeValue)
+			}
+
+			paths = append(paths, item)
+		}
+
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("line %d, column %d: %w", value.Line, value.Column, errYamlIncludeValue)
+	}
+}
+
+// flattenInclude replaces a node tagged !include with the included file.
+func (f yamlFlattener) flattenInclude(node *tree.Node, key *yaml.Node, yamlNode yaml.Node,
+	prefix config.KeyPath,
+) error {
+	if yamlNode.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d, column %d: %w", yamlNode.Line, yamlNode.Column, errYamlIncludeValue)
+	}
+
+	return f.includeFile(node, key, yamlNode.Value, &yamlNode, prefix)
+}
+
+// includeFile flattens the file at path at prefix. at is the node that
+// referenced the file, used to locate errors.
+func (f yamlFlattener) includeFile(node *tree.Node, key *yaml.Node, path string, at *yaml.Node,
+	prefix config.KeyPath,
+) error {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(f.dir, path)
+	}
+
+	path = filepath.Clean(path)
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("line %d, column %d: include %q: %w", at.Line, at.Column, path, err)
+	}
+
+	if slices.Contains(f.includes.stack, abs) {
+		chain := strings.Join(append(slices.Clone(f.includes.stack), abs), " -> ")
+
+		return fmt.Errorf("line %d, column %d: %w: %s", at.Line, at.Column, ErrIncludeCycle, chain)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("line %d, column %d: include %q: %w: %w", at.Line, at.Column, path, ErrFile, err)
+	}
+
+	var document yaml.Node
+
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		return fmt.Errorf("line %d, column %d: include %q: %w", at.Line, at.Column, path, err)
+	}
+
+	f.includes.stack = append(f.includes.stack, abs)
+	defer func() { f.includes.stack = f.includes.stack[:len(f.includes.stack)-1] }()
+
+	included := f
+	included.dir = filepath.Dir(path)
+	included.source = path
+
+	err = included.flatten(node, key, document, prefix)
+	if err != nil {
+		return fmt.Errorf("include %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// replaceYamlTarget clears an existing node before a value of another shape
+// is stored in it, as merged includes may define the same key differently.
+// A mapping stored over a mapping is merged and keeps the children.
+func replaceYamlTarget(target *tree.Node, mapping bool) {
+	if mapping && !target.IsArray() && (target.Value == nil || !target.IsLeaf()) {
+		return
+	}
+
+	target.ClearChildren()
+	target.UnmarkArray()
+
+	target.Value = nil
+}
@@ -0,0 +1,193 @@
+package collectors_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/tree"
+)
+
+func writeIncludeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestYaml_Parse_IncludeTag(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.yaml":     "app:\n  name: main\n  db: !include parts/db.yaml\n",
+		"parts/db.yaml":   "host: localhost\nport: 5432\nauth: !include auth.yaml\n",
+		"parts/auth.yaml": "# Credentials.\nuser: admin\n",
+	})
+
+	file, err := os.Open(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+
+	defer file.Close() //nolint:errcheck
+
+	root, err := collectors.NewYamlFormat(collectors.WithYamlIncludes("")).From(file).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "main", root.GetValue(config.NewKeyPath("app/name")))
+	assert.Equal(t, "localhost", root.GetValue(config.NewKeyPath("app/db/host")))
+	assert.Equal(t, int64(5432), root.GetValue(config.NewKeyPath("app/db/port")))
+	assert.Equal(t, "admin", root.GetValue(config.NewKeyPath("app/db/auth/user")))
+
+	port := root.Get(config.NewKeyPath("app/db/port"))
+	assert.Equal(t, filepath.Join(dir, "parts", "db.yaml"), port.Source)
+	assert.Equal(t, tree.NewRange(2, 7, 2, 7), port.Range)
+
+	user := root.Get(config.NewKeyPath("app/db/auth/user"))
+	assert.Equal(t, filepath.Join(dir, "parts", "auth.yaml"), user.Source)
+	assert.Equal(t, 2, user.Range.Start.Line)
+
+	assert.Empty(t, root.Get(config.NewKeyPath("app/name")).Source)
+}
+
+func TestYaml_Parse_IncludeList(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIncludeFiles(t, map[string]string{
+		"base.yaml":     "server:\n  host: base\n  port: 3301\n  roles: [a, b, c]\nlog:\n  level: info\n",
+		"override.yaml": "server:\n  host: override\nlog: verbose\n",
+	})
+
+	data := "include:\n  - base.yaml\n  - override.yaml\nserver:\n  roles: [x]\n"
+
+	root, err := collectors.NewYamlFormat(collectors.WithYamlIncludes(dir)).
+		From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"server", "log"}, root.ChildrenKeys())
+	assert.Equal(t, "override", root.GetValue(config.NewKeyPath("server/host")))
+	assert.Equal(t, int64(3301), root.GetValue(config.NewKeyPath("server/port")))
+	assert.Equal(t, "verbose", root.GetValue(config.NewKeyPath("log")))
+	assert.Equal(t, []string{"0"}, root.Get(config.NewKeyPath("server/roles")).ChildrenKeys())
+	assert.Equal(t, "x", root.GetValue(config.NewKeyPath("server/roles/0")))
+
+	assert.Equal(t, filepath.Join(dir, "base.yaml"), root.Get(config.NewKeyPath("server/port")).Source)
+	assert.Equal(t, filepath.Join(dir, "override.yaml"), root.Get(config.NewKeyPath("server/host")).Source)
+	assert.Empty(t, root.Get(config.NewKeyPath("server/roles/0")).Source)
+
+	// A single path is accepted too.
+	root, err = collectors.NewYamlFormat(collectors.WithYamlIncludes(dir)).
+		From(strings.NewReader("include: override.yaml\n")).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, "verbose", root.GetValue(config.NewKeyPath("log")))
+}
+
+func TestYaml_Parse_IncludeDisabled(t *testing.T) {
+	t.Parallel()
+
+	root, err := collectors.NewYamlFormat().
+		From(strings.NewReader("include: [a.yaml]\ndb: !include db.yaml\n")).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "a.yaml", root.GetValue(config.NewKeyPath("include/0")))
+	assert.Equal(t, "db.yaml", root.GetValue(config.NewKeyPath("db")))
+}
+
+func TestYaml_Parse_IncludeErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIncludeFiles(t, map[string]string{
+		"a.yaml":       "a: !include b.yaml\n",
+		"b.yaml":       "include: [c.yaml]\n",
+		"c.yaml":       "c: !include a.yaml\n",
+		"self.yaml":    "include: self.yaml\n",
+		"missing.yaml": "x:\n  y: !include nope.yaml\n",
+		"broken.yaml":  "a: [\n",
+		"bad.yaml":     "include: {a: b}\n",
+	})
+
+	for name, check := range map[string]func(t *testing.T, err error){
+		"a.yaml": func(t *testing.T, err error) {
+			t.Helper()
+			require.ErrorIs(t, err, collectors.ErrIncludeCycle)
+			assert.Contains(t, err.Error(),
+				filepath.Join(dir, "a.yaml")+" -> "+filepath.Join(dir, "b.yaml")+" -> "+
+					filepath.Join(dir, "c.yaml")+" -> "+filepath.Join(dir, "a.yaml"))
+		},
+		"self.yaml": func(t *testing.T, err error) {
+			t.Helper()
+			require.ErrorIs(t, err, collectors.ErrIncludeCycle)
+		},
+		"missing.yaml": func(t *testing.T, err error) {
+			t.Helper()
+			require.ErrorIs(t, err, collectors.ErrFile)
+			require.ErrorIs(t, err, fs.ErrNotExist)
+			assert.Contains(t, err.Error(), "line 2, column 6: include")
+		},
+		"broken.yaml": func(t *testing.T, err error) {
+			t.Helper()
+			require.ErrorIs(t, err, collectors.ErrUnmarshall)
+		},
+		"bad.yaml": func(t *testing.T, err error) {
+			t.Helper()
+			assert.Contains(t, err.Error(), "include must be a path or a list of paths")
+		},
+	} {
+		data := "root: !include " + name + "\n"
+
+		path := filepath.Join(dir, "main-"+name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+		_, err := collectors.NewSource(context.Background(), collectors.NewFile(path),
+			collectors.NewYamlFormat(collectors.WithYamlIncludes(dir)))
+
+		var parseErr *collectors.FormatParseError
+		require.ErrorAs(t, err, &parseErr, name)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, name)
+		check(t, err)
+	}
+}
+
+func TestYaml_Include_Builder(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.yaml":          "include: [common/defaults.yaml]\nserver:\n  port: 8080\n",
+		"common/defaults.yaml": "server:\n  host: localhost\n  port: 3301\n",
+	})
+
+	collector := collectors.NewDirectory(dir, ".yaml",
+		collectors.NewYamlFormat(collectors.WithYamlIncludes(""))).WithName("config")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	cfg, errs := builder.Build(context.Background())
+	require.Empty(t, errs)
+
+	host, ok := cfg.Lookup(config.NewKeyPath("server/host"))
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, "common", "defaults.yaml"), host.Meta().Source.Name)
+
+	port, ok := cfg.Lookup(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	assert.Equal(t, "config:"+dir+"/config.yaml", port.Meta().Source.Name)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("server/host"))
+	require.True(t, ok)
+	require.NotEmpty(t, explanation.Layers)
+	assert.Equal(t, 2, explanation.Layers[len(explanation.Layers)-1].Range.Start.Line)
+}
@@ -3,6 +3,7 @@ package collectors_test
 import (
 	"bytes"
 	_ "embed"
+	"errors"
 	"strings"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/tarantool/go-config"
 	"github.com/tarantool/go-config/collectors"
+	"go.yaml.in/yaml/v3"
 )
 
 //go:embed testdata/config.yaml
@@ -111,3 +113,183 @@ func TestYaml_Parse_Invalid(t *testing.T) {
 	require.Nil(t, root)
 	require.Error(t, err)
 }
+
+func TestYaml_Parse_MergeKeys(t *testing.T) {
+	t.Parallel()
+
+	data := `base: &base
+  timeout: 10
+  retries: 3
+  tls:
+    enabled: false
+extra: &extra
+  retries: 5
+  debug: true
+server:
+  name: main
+  <<: [*base, *extra]
+  timeout: 30
+client:
+  <<: *base
+  tls:
+    enabled: true
+inline:
+  <<: {port: 3301}
+`
+
+	root, err := collectors.NewYamlFormat().From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	server := root.Child("server")
+	require.NotNil(t, server)
+	assert.Equal(t, []string{"name", "retries", "tls", "debug", "timeout"}, server.ChildrenKeys())
+	assert.Nil(t, server.Child("<<"))
+	assert.Equal(t, int64(30), root.GetValue(config.NewKeyPath("server/timeout")))
+	assert.Equal(t, int64(3), root.GetValue(config.NewKeyPath("server/retries")))
+	assert.Equal(t, true, root.GetValue(config.NewKeyPath("server/debug")))
+	assert.Equal(t, false, root.GetValue(config.NewKeyPath("server/tls/enabled")))
+
+	assert.Equal(t, int64(10), root.GetValue(config.NewKeyPath("client/timeout")))
+	assert.Equal(t, true, root.GetValue(config.NewKeyPath("client/tls/enabled")))
+	assert.Equal(t, int64(3301), root.GetValue(config.NewKeyPath("inline/port")))
+
+	// A merged value points at its definition.
+	assert.Equal(t, 3, root.Get(config.NewKeyPath("client/retries")).Range.Start.Line)
+}
+
+func TestYaml_Parse_MergeKeys_Nested(t *testing.T) {
+	t.Parallel()
+
+	data := `a: &a {x: 1, y: 1}
+b: &b
+  <<: *a
+  y: 2
+c:
+  <<: *b
+`
+
+	root, err := collectors.NewYamlFormat().From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), root.GetValue(config.NewKeyPath("c/x")))
+	assert.Equal(t, int64(2), root.GetValue(config.NewKeyPath("c/y")))
+}
+
+func TestYaml_Parse_MergeKeys_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{"a:\n  <<: 1\n", "a:\n  <<: [{x: 1}, 2]\n"} {
+		root, err := collectors.NewYamlFormat().From(strings.NewReader(data)).Parse()
+		require.Nil(t, root, data)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, data)
+		assert.Contains(t, err.Error(), "merge key value must be a mapping", data)
+	}
+}
+
+func TestYaml_Parse_RecursiveAlias(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{
+		"a: &x\n  b: 1\n  <<: *x\n",
+		"a: &x\n  b: 1\n  <<: [*x]\n",
+		"b: &y\n  c: *y\n",
+		"l: &l [1, *l]\n",
+	} {
+		root, err := collectors.NewYamlFormat().From(strings.NewReader(data)).Parse()
+		require.Nil(t, root, data)
+		require.ErrorIs(t, err, collectors.ErrUnmarshall, data)
+		assert.Contains(t, err.Error(), "recursive alias", data)
+	}
+}
+
+func TestYaml_Parse_RepeatedAlias(t *testing.T) {
+	t.Parallel()
+
+	data := `a: &x {b: 1}
+c: *x
+d: [*x, *x]
+e:
+  <<: [*x, *x]
+`
+
+	root, err := collectors.NewYamlFormat().From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	for _, path := range []string{"c/b", "d/0/b", "d/1/b", "e/b"} {
+		assert.Equal(t, int64(1), root.GetValue(config.NewKeyPath(path)), path)
+	}
+}
+
+func TestYaml_Parse_TagHandlers(t *testing.T) {
+	t.Parallel()
+
+	data := `db:
+  password: !secret db-password
+  host: !env DB_HOST
+  replica: !ref primary
+  port: !upper 3301
+`
+
+	secrets := map[string]string{"db-password": "s3cr3t"}
+
+	format := collectors.NewYamlFormat(
+		collectors.WithYamlTag("!secret", func(node *yaml.Node) (any, error) {
+			value, ok := secrets[node.Value]
+			if !ok {
+				return nil, errors.New("unknown secret")
+			}
+
+			return value, nil
+		}),
+		collectors.WithYamlTag("!env", func(node *yaml.Node) (any, error) {
+			return "env:" + node.Value, nil
+		}),
+		collectors.WithYamlTag("!ref", func(*yaml.Node) (any, error) {
+			var node yaml.Node
+
+			err := yaml.Unmarshal([]byte("{host: primary.local, port: 3301}"), &node)
+
+			return node.Content[0], err
+		}),
+		nil,
+	)
+
+	root, err := format.From(strings.NewReader(data)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", root.GetValue(config.NewKeyPath("db/password")))
+	assert.Equal(t, "env:DB_HOST", root.GetValue(config.NewKeyPath("db/host")))
+	assert.Equal(t, "primary.local", root.GetValue(config.NewKeyPath("db/replica/host")))
+	assert.Equal(t, int64(3301), root.GetValue(config.NewKeyPath("db/replica/port")))
+	// Tags without a handler keep the plain value.
+	assert.Equal(t, "3301", root.GetValue(config.NewKeyPath("db/port")))
+	assert.Equal(t, 2, root.Get(config.NewKeyPath("db/password")).Range.Start.Line)
+
+	_, err = format.From(strings.NewReader("a: !secret missing\n")).Parse()
+	require.ErrorIs(t, err, collectors.ErrUnmarshall)
+	assert.Contains(t, err.Error(), "line 1, column 4: tag !secret: unknown secret")
+}
+
+func TestYaml_ParseDocuments(t *testing.T) {
+	t.Parallel()
+
+	data := "a: 1\n---\n---\na: 2\nb: 3\n...\n"
+
+	format, ok := collectors.NewYamlFormat().From(strings.NewReader(data)).(collectors.MultiDocumentFormat)
+	require.True(t, ok)
+
+	docs, err := format.ParseDocuments()
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, int64(1), docs[0].GetValue(config.NewKeyPath("a")))
+	assert.Equal(t, int64(2), docs[1].GetValue(config.NewKeyPath("a")))
+	assert.Equal(t, int64(3), docs[1].GetValue(config.NewKeyPath("b")))
+
+	format, ok = collectors.NewYamlFormat().From(strings.NewReader("a: 1\n---\na: [\n")).(collectors.MultiDocumentFormat)
+	require.True(t, ok)
+
+	_, err = format.ParseDocuments()
+	require.ErrorIs(t, err, collectors.ErrUnmarshall)
+	assert.Contains(t, err.Error(), "document 2")
+}
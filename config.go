@@ -127,6 +127,10 @@ type Config struct {
 
 	// tombstones records key paths deleted via MutableConfig.Delete.
 	tombstones []keypath.KeyPath
+
+	// sensitive holds the patterns of keys whose values are masked in
+	// rendered output (see Builder.WithSensitive), relative to root.
+	sensitive []keypath.KeyPath
 }
 
 // entityTombstoned reports whether entityPath, or one of its ancestor scopes,
@@ -163,6 +167,7 @@ func newConfig(root *tree.Node, inheritances []inheritanceConfig, val validator.
 		layers:       nil,
 		modified:     nil,
 		tombstones:   nil,
+		sensitive:    nil,
 	}
 }
 
@@ -181,12 +186,16 @@ func newLayeredConfig(
 		layers:       layers,
 		modified:     nil,
 		tombstones:   nil,
+		sensitive:    nil,
 	}
 }
 
 // Get is the primary, most convenient method for retrieving a value.
 // It finds the value at the specified path and extracts it into the variable `dest`.
 // Returns metadata and an error if the key is not found or the type cannot be converted.
+// A struct field tagged `required` with no value is reported as a
+// *[validator.ValidationError] with code "required". Use [Config.Decode] or
+// [GetAs] to pass [DecodeOption]s such as per-call decode hooks.
 func (c *Config) Get(path KeyPath, dest any) (MetaInfo, error) {
 	val, ok := c.Lookup(path)
 	if !ok {
@@ -195,7 +204,7 @@ func (c *Config) Get(path KeyPath, dest any) (MetaInfo, error) {
 
 	err := val.Get(dest)
 	if err != nil {
-		return val.Meta(), err
+		return val.Meta(), requiredFieldError(err)
 	}
 
 	return val.Meta(), nil
@@ -222,12 +231,12 @@ func (c *Config) Lookup(path KeyPath) (Value, bool) {
 // without touching the actual value. Useful for debugging and introspection tools.
 func (c *Config) Stat(path KeyPath) (MetaInfo, bool) {
 	if c.root == nil {
-		return MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: ""}, false
+		return MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: "", Stale: false}, false
 	}
 
 	node := c.root.Get(path)
 	if node == nil {
-		return MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: ""}, false
+		return MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: "", Stale: false}, false
 	}
 
 	// Create a temporary value to extract metadata.
@@ -254,6 +263,8 @@ func (c *Config) Validate() []error {
 		return nil
 	}
 
+	redactValidationErrors(c.sensitive, c.root, validationErrs)
+
 	errs := make([]error, len(validationErrs))
 	for i := range validationErrs {
 		errs[i] = &validationErrs[i]
@@ -346,7 +357,10 @@ func (c *Config) Slice(path KeyPath) (Config, error) {
 	}
 
 	if len(path) == 0 {
-		return newConfig(c.root, c.inheritances, nil), nil
+		slice := newConfig(c.root, c.inheritances, nil)
+		slice.sensitive = c.sensitive
+
+		return slice, nil
 	}
 
 	root := c.root.Get(path)
@@ -354,7 +368,10 @@ func (c *Config) Slice(path KeyPath) (Config, error) {
 		return Config{}, fmt.Errorf("%w: %s", ErrPathNotFound, path)
 	}
 
-	return newConfig(root, c.inheritances, nil), nil
+	slice := newConfig(root, c.inheritances, nil)
+	slice.sensitive = rebaseSensitive(c.sensitive, path)
+
+	return slice, nil
 }
 
 // Effective returns the resolved (post-inheritance) config for a specific
@@ -391,7 +408,10 @@ func (c *Config) Effective(path KeyPath) (Config, error) {
 		return Config{}, fmt.Errorf("%w: %s", ErrPathNotFound, path)
 	}
 
-	return newConfig(cloneNode(node), c.inheritances, nil), nil
+	raw := newConfig(cloneNode(node), c.inheritances, nil)
+	raw.sensitive = rebaseSensitive(c.sensitive, path)
+
+	return raw, nil
 }
 
 // EffectiveAll returns resolved configs for ALL leaf entities found in the
@@ -433,6 +453,7 @@ func (c *Config) deepClone() Config {
 		layers:       clonedLayers,
 		modified:     cloneNode(c.modified),
 		tombstones:   clonedTombstones,
+		sensitive:    c.sensitive,
 	}
 }
 
@@ -451,7 +472,10 @@ func (c *Config) resolveEntityConfig(
 			return newConfig(nil, nil, nil), false, false
 		}
 
-		return newConfig(resolveEffective(layers, inheritanceCfg), c.inheritances, nil), true, false
+		resolved := newConfig(resolveEffective(layers, inheritanceCfg), c.inheritances, nil)
+		resolved.sensitive = effectiveSensitive(c.sensitive, entityPath)
+
+		return resolved, true, false
 	}
 
 	if _, ok := matchHierarchy(c.root, inheritanceCfg, entityPath); !ok {
@@ -462,7 +486,10 @@ func (c *Config) resolveEntityConfig(
 		return newConfig(nil, nil, nil), false, true
 	}
 
-	return newConfig(resolveEffectiveLayered(c, inheritanceCfg, entityPath), c.inheritances, nil), true, false
+	resolved := newConfig(resolveEffectiveLayered(c, inheritanceCfg, entityPath), c.inheritances, nil)
+	resolved.sensitive = effectiveSensitive(c.sensitive, entityPath)
+
+	return resolved, true, false
 }
 
 // collectLeafEntities recursively finds all leaf entities in the hierarchy
@@ -542,7 +569,8 @@ func nextRevision(cur string) string {
 	return strconv.FormatUint(n+1, 10)
 }
 
-// markModified updates a node's Source and Revision to reflect a runtime modification.
+// markModified updates a node's Source and Revision to reflect a runtime
+// modification. A modified value is no longer stale.
 func markModified(node *tree.Node) {
 	if node == nil {
 		return
@@ -550,6 +578,7 @@ func markModified(node *tree.Node) {
 
 	node.Source = meta.ModifiedSourceName
 	node.Revision = nextRevision(node.Revision)
+	node.Stale = false
 }
 
 // setMutableValue replaces composite mutation values with an equivalent node
@@ -657,6 +686,7 @@ func (mc *MutableConfig) Walk(ctx context.Context, path KeyPath, depth int) (<-c
 	defer mc.mu.RUnlock()
 
 	snap := newConfig(cloneNode(mc.root), mc.inheritances, mc.validator)
+	snap.sensitive = mc.sensitive
 
 	return snap.Walk(ctx, path, depth)
 }
@@ -940,6 +970,8 @@ func (mc *MutableConfig) validateOrRestore(oldRoot *tree.Node) error {
 
 	validationErrs := mc.validator.Validate(mc.root)
 	if len(validationErrs) > 0 {
+		redactValidationErrors(mc.sensitive, mc.root, validationErrs)
+
 		mc.root = oldRoot
 
 		return &validationErrs[0]
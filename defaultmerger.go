@@ -4,6 +4,7 @@ import (
 	"slices"
 
 	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/meta"
 	"github.com/tarantool/go-config/tree"
 )
 
@@ -87,6 +88,20 @@ func (d *DefaultMerger) MergeValue(ctx MergerContext, root *tree.Node, keyPath k
 	return nil
 }
 
+// mergeSourceValue merges a value like MergeValue. A value read from another
+// file than the collector's own (e.g., a YAML include) keeps the name of that
+// file.
+func (d *DefaultMerger) mergeSourceValue(ctx MergerContext, root *tree.Node, info meta.Info, value any) error {
+	err := d.MergeValue(ctx, root, info.Key, value)
+	if err != nil {
+		return err
+	}
+
+	copySource(root, info.Key, info.Source.Name)
+
+	return nil
+}
+
 // Default is the default merger instance.
 //
 //nolint:gochecknoglobals
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+)
+
+// ChangeType classifies a single entry of a [ChangeSet].
+type ChangeType int
+
+const (
+	// ChangeAdded indicates a leaf that exists only in the newer config.
+	ChangeAdded ChangeType = iota
+	// ChangeRemoved indicates a leaf that exists only in the older config.
+	ChangeRemoved
+	// ChangeModified indicates a leaf whose value differs between the configs,
+	// or a path that turned from a leaf into a map (or vice versa).
+	ChangeModified
+	// ChangeArrayReplaced indicates an array whose contents differ. Arrays are
+	// compared and reported as a whole, since their elements have no stable
+	// identity across revisions.
+	ChangeArrayReplaced
+)
+
+// String returns a human-readable name of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeArrayReplaced:
+		return "array replaced"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes a difference at a single path between two configs.
+type Change struct {
+	// Path is the location of the changed leaf or array.
+	Path KeyPath
+	// Type classifies the change.
+	Type ChangeType
+	// OldValue is the value in the older config; nil for ChangeAdded.
+	// Arrays and maps are represented as []any and map[string]any.
+	OldValue any
+	// NewValue is the value in the newer config; nil for ChangeRemoved.
+	NewValue any
+	// OldMeta is the metadata of the value in the older config; zero for ChangeAdded.
+	OldMeta MetaInfo
+	// NewMeta is the metadata of the value in the newer config; zero for ChangeRemoved.
+	NewMeta MetaInfo
+}
+
+// ChangeSet is an ordered list of changes between two configs. Changes follow
+// the key order of the older config, with keys that appear only in the newer
+// config listed after the existing keys of their parent.
+type ChangeSet []Change
+
+// Affects reports whether any change is located at prefix or below it, or
+// replaces a subtree containing prefix (e.g., a removed parent map).
+func (cs ChangeSet) Affects(prefix KeyPath) bool {
+	for _, change := range cs {
+		if keyMatchesPrefix(change.Path, prefix) || keyMatchesPrefix(prefix, change.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Under returns the changes located at prefix or below it.
+func (cs ChangeSet) Under(prefix KeyPath) ChangeSet {
+	var result ChangeSet
+
+	for _, change := range cs {
+		if keyMatchesPrefix(change.Path, prefix) {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// Diff compares c (the older config) with other (the newer one) and returns
+// the changed leaves. Maps are descended into, so a change deep inside a
+// subtree is reported at its own path; a subtree that exists in only one of
+// the configs is reported leaf by leaf. Arrays are compared as a whole and
+// reported as [ChangeArrayReplaced]. Each change carries the metadata of the
+// old and new values, so callers can tell which source produced the update.
+//
+// A nil other is treated as an empty config. Key order is not significant:
+// two configs that differ only in the order of keys have no changes.
+//
+// Values of keys marked sensitive in either config are replaced with
+// [MaskedValue]; such keys are still reported when their values change.
+func (c *Config) Diff(other *Config) ChangeSet {
+	oldRoot, newRoot := c.root, tree.New()
+	if oldRoot == nil {
+		oldRoot = tree.New()
+	}
+
+	if other != nil && other.root != nil {
+		newRoot = other.root
+	}
+
+	var changes ChangeSet
+
+	// Roots are always compared as maps: an empty root is a childless node,
+	// which would otherwise look like a null leaf.
+	diffChildren(oldRoot, newRoot, nil, &changes)
+
+	// A key is masked if either side marks it sensitive.
+	sensitive := c.sensitive
+	if other != nil {
+		sensitive = append(slices.Clip(sensitive), other.sensitive...)
+	}
+
+	maskChanges(sensitive, changes)
+
+	return changes
+}
+
+// Diff compares the current configuration with other under the read-lock.
+// See [Config.Diff] for semantics.
+func (mc *MutableConfig) Diff(other *Config) ChangeSet {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.Config.Diff(other)
+}
+
+// diffNodes appends the differences between oldNode and newNode at path.
+func diffNodes(oldNode, newNode *tree.Node, path keypath.KeyPath, changes *ChangeSet) {
+	switch {
+	case oldNode == nil && newNode == nil:
+		return
+	case oldNode == nil:
+		diffOneSided(newNode, path, ChangeAdded, changes)
+		return
+	case newNode == nil:
+		diffOneSided(oldNode, path, ChangeRemoved, changes)
+		return
+	case isArrayNode(oldNode) || isArrayNode(newNode):
+		diffValues(oldNode, newNode, path, ChangeArrayReplaced, changes)
+		return
+	case oldNode.IsLeaf() || newNode.IsLeaf():
+		diffValues(oldNode, newNode, path, ChangeModified, changes)
+		return
+	}
+
+	diffChildren(oldNode, newNode, path, changes)
+}
+
+// diffChildren appends the differences between the children of two map nodes.
+func diffChildren(oldNode, newNode *tree.Node, path keypath.KeyPath, changes *ChangeSet) {
+	for _, key := range oldNode.ChildrenKeys() {
+		diffNodes(oldNode.Child(key), newNode.Child(key), path.Append(key), changes)
+	}
+
+	for _, key := range newNode.ChildrenKeys() {
+		if oldNode.HasChild(key) {
+			continue
+		}
+
+		diffNodes(nil, newNode.Child(key), path.Append(key), changes)
+	}
+}
+
+// diffValues records a change of changeType at path if the values of
+// oldNode and newNode differ.
+func diffValues(oldNode, newNode *tree.Node, path keypath.KeyPath, changeType ChangeType, changes *ChangeSet) {
+	oldValue := tree.ToAny(oldNode)
+	newValue := tree.ToAny(newNode)
+
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+
+	*changes = append(*changes, Change{
+		Path:     path,
+		Type:     changeType,
+		OldValue: oldValue,
+		NewValue: newValue,
+		OldMeta:  tree.NewValue(oldNode, path).Meta(),
+		NewMeta:  tree.NewValue(newNode, path).Meta(),
+	})
+}
+
+// diffOneSided records every leaf (or whole array) of a subtree that exists
+// in only one of the configs as added or removed.
+func diffOneSided(node *tree.Node, path keypath.KeyPath, changeType ChangeType, changes *ChangeSet) {
+	if !node.IsLeaf() && !isArrayNode(node) {
+		for _, key := range node.ChildrenKeys() {
+			diffOneSided(node.Child(key), path.Append(key), changeType, changes)
+		}
+
+		return
+	}
+
+	change := Change{
+		Path:     path,
+		Type:     changeType,
+		OldValue: nil,
+		NewValue: nil,
+		OldMeta:  MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: "", Stale: false},
+		NewMeta:  MetaInfo{Key: nil, Source: SourceInfo{Name: "", Type: UnknownSource}, Revision: "", Stale: false},
+	}
+
+	if changeType == ChangeAdded {
+		change.NewValue = tree.ToAny(node)
+		change.NewMeta = tree.NewValue(node, path).Meta()
+	} else {
+		change.OldValue = tree.ToAny(node)
+		change.OldMeta = tree.NewValue(node, path).Meta()
+	}
+
+	*changes = append(*changes, change)
+}
+
+// isArrayNode reports whether node holds an array, either as a sequence
+// subtree or as a leaf slice value (as produced by map-based collectors).
+func isArrayNode(node *tree.Node) bool {
+	if node.IsArray() {
+		return true
+	}
+
+	return node.IsLeaf() && node.Value != nil && reflect.TypeOf(node.Value).Kind() == reflect.Slice
+}
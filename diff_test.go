@@ -0,0 +1,197 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/meta"
+)
+
+func buildFromMap(t *testing.T, name string, data map[string]any) config.Config {
+	t.Helper()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(data).WithName(name))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	return cfg
+}
+
+func TestConfig_Diff_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 3301},
+		"tags":   []any{"a", "b"},
+	}
+
+	oldCfg := buildFromMap(t, "old", data)
+	newCfg := buildFromMap(t, "new", data)
+
+	assert.Empty(t, oldCfg.Diff(&newCfg))
+}
+
+func TestConfig_Diff_LeafChanges(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildFromMap(t, "old", map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 3301},
+		"log":    "info",
+	})
+	newCfg := buildFromMap(t, "new", map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 3302},
+		"debug":  true,
+	})
+
+	changes := oldCfg.Diff(&newCfg)
+	require.Len(t, changes, 3)
+
+	byPath := make(map[string]config.Change, len(changes))
+	for _, change := range changes {
+		byPath[change.Path.String()] = change
+	}
+
+	port := byPath["server/port"]
+	assert.Equal(t, config.ChangeModified, port.Type)
+	assert.Equal(t, 3301, port.OldValue)
+	assert.Equal(t, 3302, port.NewValue)
+	assert.Equal(t, "old", port.OldMeta.Source.Name)
+	assert.Equal(t, "new", port.NewMeta.Source.Name)
+	assert.Equal(t, config.NewKeyPath("server/port"), port.NewMeta.Key)
+
+	logLevel := byPath["log"]
+	assert.Equal(t, config.ChangeRemoved, logLevel.Type)
+	assert.Equal(t, "info", logLevel.OldValue)
+	assert.Nil(t, logLevel.NewValue)
+	assert.Equal(t, "old", logLevel.OldMeta.Source.Name)
+	assert.Empty(t, logLevel.NewMeta.Source.Name)
+
+	debug := byPath["debug"]
+	assert.Equal(t, config.ChangeAdded, debug.Type)
+	assert.Nil(t, debug.OldValue)
+	assert.Equal(t, true, debug.NewValue)
+	assert.Equal(t, "new", debug.NewMeta.Source.Name)
+}
+
+func TestConfig_Diff_SubtreeReportedLeafByLeaf(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildFromMap(t, "old", map[string]any{"a": 1})
+	newCfg := buildFromMap(t, "new", map[string]any{
+		"a":   1,
+		"tls": map[string]any{"cert": "c.pem", "key": "k.pem"},
+	})
+
+	changes := oldCfg.Diff(&newCfg)
+	require.Len(t, changes, 2)
+
+	for _, change := range changes {
+		assert.Equal(t, config.ChangeAdded, change.Type)
+	}
+
+	assert.True(t, changes.Affects(config.NewKeyPath("tls")))
+	assert.True(t, changes.Affects(config.NewKeyPath("tls/cert")))
+	assert.False(t, changes.Affects(config.NewKeyPath("a")))
+	assert.Len(t, changes.Under(config.NewKeyPath("tls")), 2)
+	assert.Empty(t, changes.Under(config.NewKeyPath("a")))
+
+	reverse := newCfg.Diff(&oldCfg)
+	require.Len(t, reverse, 2)
+
+	for _, change := range reverse {
+		assert.Equal(t, config.ChangeRemoved, change.Type)
+	}
+}
+
+func TestConfig_Diff_ArrayReplaced(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildFromMap(t, "old", map[string]any{"peers": []any{"a", "b"}})
+	newCfg := buildFromMap(t, "new", map[string]any{"peers": []any{"a", "c", "d"}})
+
+	changes := oldCfg.Diff(&newCfg)
+	require.Len(t, changes, 1)
+
+	assert.Equal(t, config.ChangeArrayReplaced, changes[0].Type)
+	assert.Equal(t, config.NewKeyPath("peers"), changes[0].Path)
+	assert.Equal(t, []any{"a", "b"}, changes[0].OldValue)
+	assert.Equal(t, []any{"a", "c", "d"}, changes[0].NewValue)
+}
+
+func TestConfig_Diff_LeafBecomesMap(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildFromMap(t, "old", map[string]any{"listen": "localhost:3301"})
+	newCfg := buildFromMap(t, "new", map[string]any{"listen": map[string]any{"uri": "localhost:3301"}})
+
+	changes := oldCfg.Diff(&newCfg)
+	require.Len(t, changes, 1)
+
+	assert.Equal(t, config.ChangeModified, changes[0].Type)
+	assert.Equal(t, "localhost:3301", changes[0].OldValue)
+	assert.Equal(t, map[string]any{"uri": "localhost:3301"}, changes[0].NewValue)
+}
+
+func TestConfig_Diff_KeyOrderIgnored(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildFromYAML(t, "a: 1\nb: 2\n").Snapshot()
+	newCfg := buildFromYAML(t, "b: 2\na: 1\n").Snapshot()
+
+	assert.Empty(t, oldCfg.Diff(&newCfg))
+}
+
+func TestConfig_Diff_NilAndEmpty(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"a": 1})
+
+	removed := cfg.Diff(nil)
+	require.Len(t, removed, 1)
+	assert.Equal(t, config.ChangeRemoved, removed[0].Type)
+
+	var empty config.Config
+
+	added := empty.Diff(&cfg)
+	require.Len(t, added, 1)
+	assert.Equal(t, config.ChangeAdded, added[0].Type)
+
+	assert.Empty(t, empty.Diff(nil))
+}
+
+func TestMutableConfig_Diff(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"port": 3301}))
+
+	mutable, errs := builder.BuildMutable(t.Context())
+	require.Empty(t, errs)
+
+	before := mutable.Snapshot()
+
+	require.NoError(t, mutable.Set(config.NewKeyPath("port"), 3302))
+
+	changes := before.Diff(&mutable.Config)
+	require.Len(t, changes, 1)
+	assert.Equal(t, meta.ModifiedSourceName, changes[0].NewMeta.Source.Name)
+
+	after := mutable.Snapshot()
+	assert.Empty(t, mutable.Diff(&after))
+}
+
+func TestChangeType_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "added", config.ChangeAdded.String())
+	assert.Equal(t, "removed", config.ChangeRemoved.String())
+	assert.Equal(t, "modified", config.ChangeModified.String())
+	assert.Equal(t, "array replaced", config.ChangeArrayReplaced.String())
+	assert.Equal(t, "unknown", config.ChangeType(-1).String())
+}
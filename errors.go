@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+
+	"github.com/tarantool/go-config/tree"
 )
 
 var (
@@ -22,8 +24,30 @@ var (
 	ErrNilCollector = errors.New("nil collector")
 	// ErrNilSchemaReader is returned by WithJSONSchema() when the schema io.Reader is nil.
 	ErrNilSchemaReader = errors.New("nil schema reader")
+	// ErrReloaderStarted is returned by Reloader.Start when it was already started.
+	ErrReloaderStarted = errors.New("reloader already started")
+	// ErrUnresolvedReference is returned when an interpolation reference cannot be resolved.
+	ErrUnresolvedReference = errors.New("unresolved reference")
+	// ErrReferenceCycle is returned when interpolation references form a cycle.
+	ErrReferenceCycle = errors.New("reference cycle")
+	// ErrInvalidReference is returned for a malformed interpolation reference.
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrTOMLUnsupported is returned by MarshalTOML for a value that cannot be represented in TOML.
+	ErrTOMLUnsupported = errors.New("value cannot be represented in TOML")
+	// ErrUnknownKey is matched by a StrictError that lists unknown keys.
+	ErrUnknownKey = tree.ErrUnknownKey
+	// ErrUnsetField is matched by a StrictError that lists unset struct fields.
+	ErrUnsetField = tree.ErrUnsetField
 )
 
+// DecodeError is returned when a value cannot be decoded into the requested
+// Go type. Its Path is the full key path of the field that failed.
+type DecodeError = tree.DecodeError
+
+// StrictError lists the unknown keys and unset struct fields found by
+// strict decoding. See [Config.GetStrict].
+type StrictError = tree.StrictError
+
 // CollectorError wraps an error that occurred while processing a collector,
 // providing context about which collector failed.
 type CollectorError struct {
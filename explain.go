@@ -0,0 +1,355 @@
+package config
+
+import (
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+)
+
+// Provenance classifies how a layer contributes a value to the configuration.
+type Provenance int
+
+const (
+	// ProvenanceOverride is a value set by a collector at the key's own
+	// location; among such values the highest-priority collector wins.
+	ProvenanceOverride Provenance = iota
+	// ProvenanceInheritance is a value set at an ancestor inheritance scope
+	// (e.g., a group or replicaset) and inherited by the entity.
+	ProvenanceInheritance
+	// ProvenanceDefault is a value from the inheritance defaults
+	// ([WithDefaults]); it has the lowest priority.
+	ProvenanceDefault
+	// ProvenanceRuntime is a value set at runtime via MutableConfig; the
+	// runtime overlay outranks every collector.
+	ProvenanceRuntime
+)
+
+// String returns a human-readable name of the provenance.
+func (p Provenance) String() string {
+	switch p {
+	case ProvenanceOverride:
+		return "override"
+	case ProvenanceInheritance:
+		return "inheritance"
+	case ProvenanceDefault:
+		return "default"
+	case ProvenanceRuntime:
+		return "runtime overlay"
+	default:
+		return "unknown"
+	}
+}
+
+// LayerValue is a single definition of a key in one configuration layer.
+type LayerValue struct {
+	// Layer is the index of the collector layer in ascending priority
+	// order (the order of Builder.AddCollector, skipping failed collectors),
+	// or -1 for defaults and the runtime overlay.
+	Layer int
+	// Scope is the inheritance scope that defined the value (e.g.,
+	// "groups/storages"); empty for the global scope and outside inheritance.
+	Scope KeyPath
+	// Provenance tells how this definition contributes to the value.
+	Provenance Provenance
+	// Meta holds the location of the definition within its layer, the
+	// source name and the revision.
+	Meta MetaInfo
+	// Range is the position of the definition in its source, if known.
+	Range tree.Range
+	// Value is the defined value. Arrays and maps are represented as []any
+	// and map[string]any.
+	Value any
+	// Excluded reports that the definition does not take part in the result
+	// because of an inheritance exclusion (WithNoInherit, WithNoInheritFrom)
+	// or a runtime deletion.
+	Excluded bool
+}
+
+// Explanation is the provenance chain of a single key.
+type Explanation struct {
+	// Path is the explained key.
+	Path KeyPath
+	// Layers lists every definition of the key in ascending priority order:
+	// defaults, then each collector layer (from the global scope down to the
+	// entity within a layer), then the runtime overlay.
+	Layers []LayerValue
+	// Winner is the index in Layers of the definition that provides the
+	// effective value, or -1 if the key has no effective value (e.g., it was
+	// deleted at runtime or every definition is excluded).
+	Winner int
+	// Reason is the provenance of the winning definition. Meaningless when
+	// Winner is -1.
+	Reason Provenance
+	// Value is the effective value of the key. It usually equals the winner's
+	// value, but differs when an inheritance merge strategy combines several
+	// definitions (e.g., MergeAppend or MergeDeep on a map).
+	Value any
+}
+
+// Winning returns the definition that provides the effective value, if any.
+func (e Explanation) Winning() (LayerValue, bool) {
+	if e.Winner < 0 || e.Winner >= len(e.Layers) {
+		return LayerValue{}, false //nolint:exhaustruct
+	}
+
+	return e.Layers[e.Winner], true
+}
+
+// Explain lists every layer that defines the key at path, in priority order,
+// and marks which definition provides the effective value and why. It answers
+// the question "where does this value come from?" that [Config.Stat] answers
+// only for the winning layer.
+//
+// A path below a leaf entity of a registered inheritance hierarchy (e.g.,
+// "groups/g/replicasets/r/instances/i/iproto/listen") is explained against the
+// entity's effective configuration: definitions at every ancestor scope and
+// in the inheritance defaults are included. Any other path is explained
+// against the per-collector layers directly.
+//
+// The second result is false when no layer defines the key and it has no
+// effective value. Values of sensitive keys are replaced with [MaskedValue].
+func (c *Config) Explain(path KeyPath) (Explanation, bool) {
+	explanation := Explanation{
+		Path:   path,
+		Layers: nil,
+		Winner: -1,
+		Reason: ProvenanceOverride,
+		Value:  nil,
+	}
+
+	if c.root == nil {
+		return explanation, false
+	}
+
+	var effective *tree.Node
+
+	inheritanceCfg, entityPath, configKey := c.matchInheritedKey(path)
+	if inheritanceCfg != nil {
+		explanation.Layers = c.explainInherited(inheritanceCfg, entityPath, configKey)
+
+		resolved, matched, _ := c.resolveEntityConfig(inheritanceCfg, entityPath)
+		if matched && resolved.root != nil {
+			effective = resolved.root.Get(configKey)
+		}
+	} else {
+		explanation.Layers = c.explainRaw(path)
+		effective = c.root.Get(path)
+	}
+
+	if effective != nil {
+		explanation.Value = tree.ToAny(effective)
+
+		for i := len(explanation.Layers) - 1; i >= 0; i-- {
+			if !explanation.Layers[i].Excluded {
+				explanation.Winner = i
+				explanation.Reason = explanation.Layers[i].Provenance
+
+				break
+			}
+		}
+	}
+
+	c.maskExplanation(&explanation)
+
+	return explanation, effective != nil || len(explanation.Layers) > 0
+}
+
+// Explain returns the provenance chain of a key under the read-lock.
+// See [Config.Explain] for semantics.
+func (mc *MutableConfig) Explain(path KeyPath) (Explanation, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.Config.Explain(path)
+}
+
+// maskExplanation masks the sensitive values of explanation. A definition is
+// masked when either the explained path or the definition's own location
+// (e.g., the group scope it is inherited from) is sensitive.
+func (c *Config) maskExplanation(explanation *Explanation) {
+	if len(c.sensitive) == 0 {
+		return
+	}
+
+	explanation.Value = maskSensitive(c.sensitive, explanation.Path, explanation.Value)
+
+	for i := range explanation.Layers {
+		layer := &explanation.Layers[i]
+		layer.Value = maskSensitive(c.sensitive, explanation.Path, layer.Value)
+		layer.Value = maskSensitive(c.sensitive, layer.Meta.Key, layer.Value)
+		explanation.Value = maskSensitive(c.sensitive, layer.Meta.Key, explanation.Value)
+	}
+}
+
+// matchInheritedKey splits path into a leaf entity of a registered hierarchy
+// and a config key below it. It returns a nil hierarchy when path does not
+// address a config key of a leaf entity.
+func (c *Config) matchInheritedKey(path KeyPath) (*inheritanceConfig, KeyPath, KeyPath) {
+	for i := range c.inheritances {
+		inheritanceCfg := &c.inheritances[i]
+
+		entityLen := (len(inheritanceCfg.levels) - 1) * segmentsPerLevel
+		if len(path) <= entityLen || isStructuralKey(inheritanceCfg, path[entityLen]) {
+			continue
+		}
+
+		if _, ok := matchHierarchy(c.root, inheritanceCfg, path[:entityLen]); !ok {
+			continue
+		}
+
+		return inheritanceCfg, path[:entityLen], path[entityLen:]
+	}
+
+	return nil, nil, nil
+}
+
+// explainRaw collects the definitions of path in every collector layer and
+// the runtime overlay, without inheritance.
+func (c *Config) explainRaw(path KeyPath) []LayerValue {
+	layers := c.layers
+	if len(layers) == 0 {
+		// Not produced by a Builder: the merged tree is the only layer.
+		layers = []*tree.Node{c.root}
+	}
+
+	deleted := entityTombstoned(c.tombstones, path)
+
+	var result []LayerValue
+
+	for i, layer := range layers {
+		node := layer.Get(path)
+		if node == nil {
+			continue
+		}
+
+		result = append(result, newLayerValue(node, path, i, nil, ProvenanceOverride, deleted))
+	}
+
+	if c.modified != nil {
+		if node := c.modified.Get(path); node != nil {
+			result = append(result, newLayerValue(node, path, -1, nil, ProvenanceRuntime, false))
+		}
+	}
+
+	return result
+}
+
+// explainInherited collects the definitions of configKey for the leaf entity
+// at entityPath: inheritance defaults, every scope of every collector layer
+// and the runtime overlay, in the order resolveEffectiveLayered applies them.
+func (c *Config) explainInherited(
+	inheritanceCfg *inheritanceConfig,
+	entityPath, configKey keypath.KeyPath,
+) []LayerValue {
+	var result []LayerValue
+
+	if inheritanceCfg.defaults != nil {
+		defaults := tree.New()
+		mergeDefaults(defaults, inheritanceCfg.defaults)
+
+		if node := defaults.Get(configKey); node != nil {
+			result = append(result, newLayerValue(node, configKey, -1, nil, ProvenanceDefault, false))
+		}
+	}
+
+	layers := c.layers
+	if len(layers) == 0 {
+		layers = []*tree.Node{c.root}
+	}
+
+	suppressedByLevel := buildSuppressedByLevel(c.tombstones, inheritanceCfg, entityPath)
+	deleted := entityTombstoned(c.tombstones, entityPath)
+
+	for i, layer := range layers {
+		result = append(result, explainScopeChain(
+			layer, inheritanceCfg, entityPath, configKey, i, suppressedByLevel, deleted)...)
+	}
+
+	if c.modified != nil {
+		for _, value := range explainScopeChain(
+			c.modified, inheritanceCfg, entityPath, configKey, -1, nil, deleted) {
+			value.Provenance = ProvenanceRuntime
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// explainScopeChain collects the definitions of configKey at every scope of
+// one layer, from the global scope down to the entity itself.
+func explainScopeChain(
+	layer *tree.Node,
+	inheritanceCfg *inheritanceConfig,
+	entityPath, configKey keypath.KeyPath,
+	layerIdx int,
+	suppressedByLevel map[int][]keypath.KeyPath,
+	deleted bool,
+) []LayerValue {
+	scopeChain, ok := matchHierarchy(layer, inheritanceCfg, entityPath)
+	if !ok {
+		return nil
+	}
+
+	leafIdx := len(scopeChain) - 1
+
+	var result []LayerValue
+
+	for levelIdx, scopeNode := range scopeChain {
+		if scopeNode == nil {
+			continue
+		}
+
+		node := scopeNode.Get(configKey)
+		if node == nil {
+			continue
+		}
+
+		scope := entityPath[:levelIdx*segmentsPerLevel]
+
+		provenance := ProvenanceOverride
+		if levelIdx < leafIdx {
+			provenance = ProvenanceInheritance
+		}
+
+		excluded := deleted ||
+			(levelIdx < leafIdx && !inheritanceCfg.shouldInherit(levelIdx, configKey)) ||
+			suppressedAt(suppressedByLevel[levelIdx], configKey)
+
+		path := append(append(keypath.KeyPath{}, scope...), configKey...)
+
+		result = append(result, newLayerValue(node, path, layerIdx, scope, provenance, excluded))
+	}
+
+	return result
+}
+
+// suppressedAt reports whether key or one of its ancestors is in suppressed.
+func suppressedAt(suppressed []keypath.KeyPath, key keypath.KeyPath) bool {
+	for _, prefix := range suppressed {
+		if keyMatchesPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newLayerValue describes the definition held by node at path.
+func newLayerValue(
+	node *tree.Node,
+	path keypath.KeyPath,
+	layerIdx int,
+	scope keypath.KeyPath,
+	provenance Provenance,
+	excluded bool,
+) LayerValue {
+	return LayerValue{
+		Layer:      layerIdx,
+		Scope:      scope,
+		Provenance: provenance,
+		Meta:       tree.NewValue(node, path).Meta(),
+		Range:      node.Range,
+		Value:      tree.ToAny(node),
+		Excluded:   excluded,
+	}
+}
@@ -0,0 +1,255 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/meta"
+	"github.com/tarantool/go-config/tree"
+)
+
+const explainInstance = "groups/g/replicasets/r/instances/i"
+
+func TestConfig_Explain_Override(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"port": 3301}).WithName("base"))
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"host": "h"}).WithName("other"))
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"port": 3302}).WithName("override"))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("port"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 2)
+
+	assert.Equal(t, 0, explanation.Layers[0].Layer)
+	assert.Equal(t, "base", explanation.Layers[0].Meta.Source.Name)
+	assert.Equal(t, 3301, explanation.Layers[0].Value)
+
+	assert.Equal(t, 2, explanation.Layers[1].Layer)
+	assert.Equal(t, "override", explanation.Layers[1].Meta.Source.Name)
+	assert.Equal(t, 3302, explanation.Layers[1].Value)
+
+	assert.Equal(t, 1, explanation.Winner)
+	assert.Equal(t, config.ProvenanceOverride, explanation.Reason)
+	assert.Equal(t, 3302, explanation.Value)
+
+	winner, ok := explanation.Winning()
+	require.True(t, ok)
+	assert.Equal(t, "override", winner.Meta.Source.Name)
+}
+
+func TestConfig_Explain_NotFound(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"port": 3301})
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("missing"))
+	assert.False(t, ok)
+	assert.Empty(t, explanation.Layers)
+	assert.Equal(t, -1, explanation.Winner)
+
+	_, ok = explanation.Winning()
+	assert.False(t, ok)
+}
+
+func TestConfig_Explain_Range(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  host: localhost\n  port: 3301\n"), 0o600))
+
+	col, err := collectors.NewSource(t.Context(), collectors.NewFile(path), collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("server/port"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 1)
+
+	assert.Equal(t, tree.NewRange(3, 9, 3, 9), explanation.Layers[0].Range)
+	assert.Equal(t, int64(3301), explanation.Value)
+}
+
+func TestConfig_Explain_Inheritance(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"log": "info",
+		"groups": map[string]any{
+			"g": map[string]any{
+				"log": "warn",
+				"replicasets": map[string]any{
+					"r": map[string]any{
+						"instances": map[string]any{
+							"i": map[string]any{"port": 3301},
+						},
+					},
+				},
+			},
+		},
+	}).WithName("file"))
+	builder = builder.WithInheritance(
+		config.Levels(config.Global, "groups", "replicasets", "instances"),
+		config.WithDefaults(config.DefaultsType{"log": "error", "memtx": map[string]any{"memory": 1}}),
+	)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath(explainInstance + "/log"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 3)
+
+	assert.Equal(t, config.ProvenanceDefault, explanation.Layers[0].Provenance)
+	assert.Equal(t, -1, explanation.Layers[0].Layer)
+	assert.Equal(t, "error", explanation.Layers[0].Value)
+
+	assert.Equal(t, config.ProvenanceInheritance, explanation.Layers[1].Provenance)
+	assert.Empty(t, explanation.Layers[1].Scope)
+	assert.Equal(t, config.NewKeyPath("log"), explanation.Layers[1].Meta.Key)
+
+	assert.Equal(t, config.ProvenanceInheritance, explanation.Layers[2].Provenance)
+	assert.Equal(t, config.NewKeyPath("groups/g"), explanation.Layers[2].Scope)
+	assert.Equal(t, config.NewKeyPath("groups/g/log"), explanation.Layers[2].Meta.Key)
+	assert.Equal(t, "file", explanation.Layers[2].Meta.Source.Name)
+
+	assert.Equal(t, 2, explanation.Winner)
+	assert.Equal(t, config.ProvenanceInheritance, explanation.Reason)
+	assert.Equal(t, "warn", explanation.Value)
+
+	defaults, ok := cfg.Explain(config.NewKeyPath(explainInstance + "/memtx/memory"))
+	require.True(t, ok)
+	require.Len(t, defaults.Layers, 1)
+	assert.Equal(t, config.ProvenanceDefault, defaults.Reason)
+	assert.Equal(t, 1, defaults.Value)
+
+	own, ok := cfg.Explain(config.NewKeyPath(explainInstance + "/port"))
+	require.True(t, ok)
+	require.Len(t, own.Layers, 1)
+	assert.Equal(t, config.ProvenanceOverride, own.Reason)
+	assert.Equal(t, config.NewKeyPath(explainInstance), own.Layers[0].Scope)
+}
+
+func TestConfig_Explain_InheritanceAcrossLayers(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"groups": map[string]any{
+			"g": map[string]any{
+				"replicasets": map[string]any{
+					"r": map[string]any{
+						"instances": map[string]any{
+							"i": map[string]any{"log": "debug"},
+						},
+					},
+				},
+			},
+		},
+	}).WithName("file"))
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"log": "warn"}).WithName("env"))
+	builder = builder.WithInheritance(config.Levels(config.Global, "groups", "replicasets", "instances"))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath(explainInstance + "/log"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 2)
+
+	// A higher-priority loader wins regardless of the scope it sits in.
+	assert.Equal(t, 1, explanation.Winner)
+	assert.Equal(t, "env", explanation.Layers[1].Meta.Source.Name)
+	assert.Equal(t, config.ProvenanceInheritance, explanation.Reason)
+	assert.Equal(t, "warn", explanation.Value)
+}
+
+func TestConfig_Explain_NoInheritExcluded(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"groups": map[string]any{
+			"g": map[string]any{
+				"replicasets": map[string]any{
+					"r": map[string]any{
+						"leader": "i",
+						"instances": map[string]any{
+							"i": map[string]any{"port": 3301},
+						},
+					},
+				},
+			},
+		},
+	}))
+	builder = builder.WithInheritance(
+		config.Levels(config.Global, "groups", "replicasets", "instances"),
+		config.WithNoInherit("leader"),
+	)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath(explainInstance + "/leader"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 1)
+	assert.True(t, explanation.Layers[0].Excluded)
+	assert.Equal(t, -1, explanation.Winner)
+	assert.Nil(t, explanation.Value)
+}
+
+func TestMutableConfig_Explain_RuntimeOverlay(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"port": 3301}).WithName("base"))
+
+	cfg, errs := builder.BuildMutable(t.Context())
+	require.Empty(t, errs)
+
+	require.NoError(t, cfg.Set(config.NewKeyPath("port"), 3302))
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("port"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 2)
+
+	assert.Equal(t, 1, explanation.Winner)
+	assert.Equal(t, config.ProvenanceRuntime, explanation.Reason)
+	assert.Equal(t, meta.ModifiedSourceName, explanation.Layers[1].Meta.Source.Name)
+	assert.Equal(t, 3302, explanation.Value)
+
+	require.True(t, cfg.Delete(config.NewKeyPath("port")))
+
+	explanation, ok = cfg.Explain(config.NewKeyPath("port"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 1)
+	assert.True(t, explanation.Layers[0].Excluded)
+	assert.Equal(t, -1, explanation.Winner)
+}
+
+func TestProvenance_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "override", config.ProvenanceOverride.String())
+	assert.Equal(t, "inheritance", config.ProvenanceInheritance.String())
+	assert.Equal(t, "default", config.ProvenanceDefault.String())
+	assert.Equal(t, "runtime overlay", config.ProvenanceRuntime.String())
+	assert.Equal(t, "unknown", config.Provenance(-1).String())
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-config/tree"
+	"github.com/tarantool/go-config/validator"
+)
+
+// Getter is implemented by [Config] and [MutableConfig]. It is the read
+// access used by the generic helpers [GetAs], [MustGet] and [GetOr].
+type Getter interface {
+	Get(path KeyPath, dest any) (MetaInfo, error)
+	Decode(path KeyPath, dest any, opts ...DecodeOption) error
+}
+
+// GetAs returns the value at path decoded into T.
+//
+//	port, err := config.GetAs[int](&cfg, config.NewKeyPath("server/port"))
+//
+// The error wraps [ErrKeyNotFound] if the key does not exist, or is a
+// *[DecodeError] if the value cannot be converted to T. Options configure
+// the decoding as for [Config.Decode], e.g. [WithDecodeHooks].
+func GetAs[T any](cfg Getter, path KeyPath, opts ...DecodeOption) (T, error) {
+	var (
+		result T
+		err    error
+	)
+
+	if len(opts) == 0 {
+		_, err = cfg.Get(path, &result)
+	} else {
+		err = cfg.Decode(path, &result, opts...)
+	}
+
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return result, nil
+}
+
+// MustGet is like [GetAs] but panics if the key does not exist or the value
+// cannot be converted to T. Useful for keys guaranteed by a schema.
+func MustGet[T any](cfg Getter, path KeyPath, opts ...DecodeOption) T {
+	result, err := GetAs[T](cfg, path, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// GetOr returns the value at path decoded into T, or fallback if the key
+// does not exist or the value cannot be converted to T.
+func GetOr[T any](cfg Getter, path KeyPath, fallback T, opts ...DecodeOption) T {
+	result, err := GetAs[T](cfg, path, opts...)
+	if err != nil {
+		return fallback
+	}
+
+	return result
+}
+
+// Decode decodes the whole subtree at path into dest, which must be a
+// non-nil pointer (typically to a struct). An empty path decodes the entire
+// configuration. Struct fields are matched by their `yaml` tag name, falling
+// back to the field name. The struct tags understood by the decoder are:
+//
+//   - `yaml:"name,required"` — the key must be present;
+//   - `yaml:"name,omitempty"` — an empty value (null, "", empty map or
+//     list) is treated as missing;
+//   - `default:"value"` — used when the key is missing; the value is parsed
+//     as YAML, so `default:"[a, b]"` fills a slice and `default:"5s"` a
+//     time.Duration.
+//
+// The error wraps [ErrKeyNotFound] if path does not exist. A conversion
+// failure is reported as a *[DecodeError] whose Path is the full key path of
+// the field that failed, e.g. "server/tls/port" rather than just "server".
+// A missing required field is reported as a *[validator.ValidationError]
+// with code "required" and the field's key path.
+//
+// Options configure this call only, e.g. [WithDecodeHooks] adds hooks for
+// custom types on top of those registered with [RegisterDecodeHook].
+func (c *Config) Decode(path KeyPath, dest any, opts ...DecodeOption) error {
+	if c.root == nil {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+	}
+
+	node := c.root.Get(path)
+	if node == nil {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+	}
+
+	return requiredFieldError(tree.Decode(node, path, dest, opts...))
+}
+
+// Decode decodes the subtree at path into dest with read-lock protection.
+// See [Config.Decode] for semantics.
+func (mc *MutableConfig) Decode(path KeyPath, dest any, opts ...DecodeOption) error {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.Config.Decode(path, dest, opts...)
+}
+
+// GetStrict is like [Config.Get] but fails when the value and dest do not
+// match exactly: every key without a matching struct field (a typo such as
+// "iproto/listne") and every struct field without a matching key is
+// reported, with its full key path, in a *[StrictError]. dest is still
+// filled, so the error may be logged and ignored.
+//
+// Fields filled by a `default` tag or tagged `omitempty` are not reported as
+// unset. Use [WithStrict] to get the same behavior from [Config.Decode].
+func (c *Config) GetStrict(path KeyPath, dest any) (MetaInfo, error) {
+	val, ok := c.Lookup(path)
+	if !ok {
+		return MetaInfo{}, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+	}
+
+	err := c.Decode(path, dest, WithStrict())
+	if err != nil {
+		return val.Meta(), err
+	}
+
+	return val.Meta(), nil
+}
+
+// GetStrict strictly decodes the value at path with read-lock protection.
+// See [Config.GetStrict] for semantics.
+func (mc *MutableConfig) GetStrict(path KeyPath, dest any) (MetaInfo, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.Config.GetStrict(path, dest)
+}
+
+// WithStrict makes a [Config.Decode] call report unknown keys and unset
+// struct fields in a *[StrictError]. See [Config.GetStrict].
+func WithStrict() DecodeOption {
+	return tree.WithStrict()
+}
+
+// DecodeHook converts raw configuration values into one specific Go type.
+// See [NewDecodeHook].
+type DecodeHook = tree.DecodeHook
+
+// DecodeOption configures a single [Config.Decode] or [GetAs] call.
+type DecodeOption = tree.DecodeOption
+
+// ByteSize is a size in bytes decoded from strings like "512M" or "1GiB".
+// See [tree.ByteSize] for the accepted format.
+type ByteSize = tree.ByteSize
+
+// NewDecodeHook creates a hook that decodes values of type T from raw
+// configuration values (primitives, []any or map[string]any).
+//
+// Out of the box the decoder already handles types implementing
+// encoding.TextUnmarshaler (net.IP, *regexp.Regexp, [ByteSize], ...),
+// url.URL and time.Time; a hook for one of these types overrides the
+// built-in conversion.
+func NewDecodeHook[T any](fn func(src any) (T, error)) DecodeHook {
+	return tree.NewDecodeHook(fn)
+}
+
+// RegisterDecodeHook registers hooks globally, for every decode in the
+// process (Get, Decode and the generic accessors). Register hooks during
+// initialization; per-call hooks passed via [WithDecodeHooks] take precedence.
+func RegisterDecodeHook(hooks ...DecodeHook) {
+	tree.RegisterDecodeHook(hooks...)
+}
+
+// WithDecodeHooks adds hooks used by a single [Config.Decode] or [GetAs] call.
+func WithDecodeHooks(hooks ...DecodeHook) DecodeOption {
+	return tree.WithDecodeHooks(hooks...)
+}
+
+// requiredFieldError reports a missing required struct field, found while
+// decoding, as a *validator.ValidationError so that it reads like a schema
+// violation. Any other error is returned unchanged.
+func requiredFieldError(err error) error {
+	var decodeErr *DecodeError
+	if !errors.Is(err, tree.ErrRequiredField) || !errors.As(err, &decodeErr) {
+		return err
+	}
+
+	return &validator.ValidationError{
+		Path:    decodeErr.Path,
+		Range:   validator.NewEmptyRange(),
+		Code:    "required",
+		Message: "required field is missing",
+	}
+}
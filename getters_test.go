@@ -0,0 +1,292 @@
+package config_test
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/validator"
+)
+
+type decodeTLS struct {
+	Enabled bool   `yaml:"enabled"`
+	Cert    string `yaml:"cert"`
+}
+
+type decodeServer struct {
+	Host    string        `yaml:"host"`
+	Port    int           `yaml:"port"`
+	Timeout time.Duration `yaml:"timeout"`
+	TLS     decodeTLS     `yaml:"tls"`
+}
+
+func TestGetAs(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"server": map[string]any{"port": 3301, "timeout": "5s", "host": "localhost"},
+	})
+
+	port, err := config.GetAs[int](&cfg, config.NewKeyPath("server/port"))
+	require.NoError(t, err)
+	assert.Equal(t, 3301, port)
+
+	timeout, err := config.GetAs[time.Duration](&cfg, config.NewKeyPath("server/timeout"))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	_, err = config.GetAs[int](&cfg, config.NewKeyPath("server/missing"))
+	require.ErrorIs(t, err, config.ErrKeyNotFound)
+
+	_, err = config.GetAs[int](&cfg, config.NewKeyPath("server/host"))
+
+	var decodeErr *config.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, config.NewKeyPath("server/host"), decodeErr.Path)
+}
+
+func TestGetAs_MutableConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromYAML(t, "port: 3301\n")
+
+	require.NoError(t, cfg.Set(config.NewKeyPath("port"), 3302))
+
+	port, err := config.GetAs[int](cfg, config.NewKeyPath("port"))
+	require.NoError(t, err)
+	assert.Equal(t, 3302, port)
+}
+
+func TestMustGet(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"name": "app"})
+
+	assert.Equal(t, "app", config.MustGet[string](&cfg, config.NewKeyPath("name")))
+	assert.Panics(t, func() {
+		config.MustGet[string](&cfg, config.NewKeyPath("missing"))
+	})
+}
+
+func TestGetOr(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"workers": 4, "mode": "fast"})
+
+	assert.Equal(t, 4, config.GetOr(&cfg, config.NewKeyPath("workers"), 1))
+	assert.Equal(t, 1, config.GetOr(&cfg, config.NewKeyPath("missing"), 1))
+	assert.Equal(t, 1, config.GetOr(&cfg, config.NewKeyPath("mode"), 1))
+}
+
+func TestConfig_Decode(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"server": map[string]any{
+			"host":    "localhost",
+			"port":    3301,
+			"timeout": 30,
+			"tls":     map[string]any{"enabled": true, "cert": "c.pem"},
+		},
+	})
+
+	var server decodeServer
+
+	require.NoError(t, cfg.Decode(config.NewKeyPath("server"), &server))
+	assert.Equal(t, decodeServer{
+		Host:    "localhost",
+		Port:    3301,
+		Timeout: 30 * time.Second,
+		TLS:     decodeTLS{Enabled: true, Cert: "c.pem"},
+	}, server)
+
+	var root struct {
+		Server decodeServer `yaml:"server"`
+	}
+
+	require.NoError(t, cfg.Decode(nil, &root))
+	assert.Equal(t, server, root.Server)
+}
+
+func TestConfig_Decode_ErrorPath(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"server": map[string]any{
+			"port": 3301,
+			"tls":  map[string]any{"enabled": "maybe"},
+		},
+	})
+
+	var server decodeServer
+
+	err := cfg.Decode(config.NewKeyPath("server"), &server)
+
+	var decodeErr *config.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, config.NewKeyPath("server/tls/enabled"), decodeErr.Path)
+	assert.Contains(t, err.Error(), "server/tls/enabled")
+}
+
+func TestConfig_Decode_NotFound(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"a": 1})
+
+	var server decodeServer
+
+	require.ErrorIs(t, cfg.Decode(config.NewKeyPath("server"), &server), config.ErrKeyNotFound)
+
+	var empty config.Config
+
+	require.ErrorIs(t, empty.Decode(nil, &server), config.ErrKeyNotFound)
+}
+
+func TestMutableConfig_Decode(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromYAML(t, "server:\n  host: localhost\n  port: 3301\n")
+
+	var server decodeServer
+
+	require.NoError(t, cfg.Decode(config.NewKeyPath("server"), &server))
+	assert.Equal(t, "localhost", server.Host)
+	assert.Equal(t, 3301, server.Port)
+}
+
+func TestConfig_Decode_DefaultsAndRequired(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host    string        `yaml:"host,required"`
+		Port    int           `default:"3301" yaml:"port"`
+		Timeout time.Duration `default:"10s"  yaml:"timeout"`
+	}
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"good": map[string]any{"host": "localhost"},
+		"bad":  map[string]any{"port": 3302},
+	})
+
+	var good server
+
+	require.NoError(t, cfg.Decode(config.NewKeyPath("good"), &good))
+	assert.Equal(t, server{Host: "localhost", Port: 3301, Timeout: 10 * time.Second}, good)
+
+	var bad server
+
+	err := cfg.Decode(config.NewKeyPath("bad"), &bad)
+
+	var validationErr *validator.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "required", validationErr.Code)
+	assert.Equal(t, config.NewKeyPath("bad/host"), validationErr.Path)
+}
+
+func TestConfig_Decode_Hooks(t *testing.T) {
+	t.Parallel()
+
+	type limits struct {
+		Memory config.ByteSize `yaml:"memory"`
+		Listen net.IP          `yaml:"listen"`
+		Mode   string          `yaml:"mode"`
+	}
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"limits": map[string]any{"memory": "1GiB", "listen": "127.0.0.1", "mode": "rw"},
+	})
+
+	var dest limits
+
+	require.NoError(t, cfg.Decode(config.NewKeyPath("limits"), &dest,
+		config.WithDecodeHooks(config.NewDecodeHook(func(src any) (string, error) {
+			return strings.ToUpper(fmt.Sprint(src)), nil
+		}))))
+	assert.Equal(t, config.ByteSize(1<<30), dest.Memory)
+	assert.Equal(t, net.ParseIP("127.0.0.1"), dest.Listen)
+	assert.Equal(t, "RW", dest.Mode)
+}
+
+func TestGetAs_DecodeHooks(t *testing.T) {
+	t.Parallel()
+
+	upper := config.WithDecodeHooks(config.NewDecodeHook(func(src any) (string, error) {
+		return strings.ToUpper(fmt.Sprint(src)), nil
+	}))
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"mode": "rw"})
+	path := config.NewKeyPath("mode")
+
+	mode, err := config.GetAs[string](&cfg, path, upper)
+	require.NoError(t, err)
+	assert.Equal(t, "RW", mode)
+
+	assert.Equal(t, "RW", config.MustGet[string](&cfg, path, upper))
+	assert.Equal(t, "RW", config.GetOr(&cfg, path, "ro", upper))
+	assert.Equal(t, "ro", config.GetOr(&cfg, config.NewKeyPath("missing"), "ro", upper))
+	assert.Equal(t, "rw", config.MustGet[string](&cfg, path))
+
+	mutable := buildFromYAML(t, "mode: rw\n")
+	assert.Equal(t, "RW", config.MustGet[string](mutable, path, upper))
+
+	_, err = config.GetAs[string](&cfg, config.NewKeyPath("missing"), upper)
+	require.ErrorIs(t, err, config.ErrKeyNotFound)
+}
+
+func TestConfig_GetStrict(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{
+		"app": map[string]any{
+			"iproto": map[string]any{"listne": "localhost:3301"},
+		},
+	})
+
+	type appConfig struct {
+		IProto struct {
+			Listen string `yaml:"listen"`
+		} `yaml:"iproto"`
+	}
+
+	var dest appConfig
+
+	_, err := cfg.Get(config.NewKeyPath("app"), &dest)
+	require.NoError(t, err)
+
+	meta, err := cfg.GetStrict(config.NewKeyPath("app"), &dest)
+	require.ErrorIs(t, err, config.ErrUnknownKey)
+	require.ErrorIs(t, err, config.ErrUnsetField)
+	assert.Equal(t, config.NewKeyPath("app"), meta.Key)
+
+	var strictErr *config.StrictError
+	require.ErrorAs(t, err, &strictErr)
+	assert.Equal(t, []config.KeyPath{config.NewKeyPath("app/iproto/listne")}, strictErr.UnknownKeys)
+	assert.Equal(t, []config.KeyPath{config.NewKeyPath("app/iproto/listen")}, strictErr.UnsetFields)
+
+	err = cfg.Decode(config.NewKeyPath("app"), &dest, config.WithStrict())
+	require.ErrorAs(t, err, &strictErr)
+
+	_, err = cfg.GetStrict(config.NewKeyPath("missing"), &dest)
+	require.ErrorIs(t, err, config.ErrKeyNotFound)
+}
+
+func TestMutableConfig_GetStrict(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromYAML(t, "server:\n  host: localhost\n  port: 3301\n")
+
+	var server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+
+	_, err := cfg.GetStrict(config.NewKeyPath("server"), &server)
+	require.NoError(t, err)
+	assert.Equal(t, 3301, server.Port)
+}
@@ -184,9 +184,14 @@ func cloneNode(node *tree.Node) *tree.Node {
 	clone.Value = node.Value
 	clone.Source = node.Source
 	clone.Revision = node.Revision
+	clone.Stale = node.Stale
 	clone.Range = node.Range
 	clone.SetAnnotation(node.Annotation())
 
+	if unexpanded, ok := node.Unexpanded(); ok {
+		clone.SetUnexpanded(unexpanded)
+	}
+
 	if node.IsArray() {
 		clone.MarkArray()
 	}
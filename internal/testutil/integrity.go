@@ -10,10 +10,16 @@ import (
 // NewRawTyped creates an *integrity.Typed[[]byte] with no hashers or
 // signers, using a raw passthrough marshaller for byte handling.
 func NewRawTyped(strg storage.Storage, prefix string) *integrity.Typed[[]byte] {
+	return NewRawTypedBuilder(strg, prefix).Build()
+}
+
+// NewRawTypedBuilder returns an integrity.TypedBuilder[[]byte] with the
+// prefix and a raw passthrough marshaller set, so tests can add hashers and
+// signers before building.
+func NewRawTypedBuilder(strg storage.Storage, prefix string) integrity.TypedBuilder[[]byte] {
 	return integrity.NewTypedBuilder[[]byte](strg).
 		WithPrefix(prefix).
-		WithMarshaller(rawBytesMarshaller{}).
-		Build()
+		WithMarshaller(rawBytesMarshaller{})
 }
 
 // rawBytesMarshaller passes bytes through without any encoding/decoding.
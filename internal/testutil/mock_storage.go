@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"sort"
@@ -18,7 +19,8 @@ import (
 
 // MockStorage is an in-memory implementation of storage.Storage for testing.
 // It supports Get (with prefix matching), Put, and Delete operations,
-// and optionally injects errors.
+// transactions conditioned on version and value predicates, and optionally
+// injects errors.
 type MockStorage struct {
 	mu   sync.RWMutex
 	data map[string]kv.KeyValue
@@ -140,6 +142,7 @@ type mockTx struct {
 	err        error
 	predicates []predicate.Predicate
 	ops        []operation.Operation
+	elseOps    []operation.Operation
 }
 
 // If implements tx.Tx.
@@ -155,11 +158,13 @@ func (t *mockTx) Then(ops ...operation.Operation) tx.Tx {
 }
 
 // Else implements tx.Tx.
-func (t *mockTx) Else(_ ...operation.Operation) tx.Tx {
+func (t *mockTx) Else(ops ...operation.Operation) tx.Tx {
+	t.elseOps = append(t.elseOps, ops...)
 	return t
 }
 
-// Commit implements tx.Tx.
+// Commit implements tx.Tx. The Then operations are executed if all
+// predicates hold, the Else operations otherwise.
 func (t *mockTx) Commit() (tx.Response, error) {
 	if t.err != nil {
 		return tx.Response{}, t.err
@@ -168,9 +173,23 @@ func (t *mockTx) Commit() (tx.Response, error) {
 	t.storage.mu.Lock()
 	defer t.storage.mu.Unlock()
 
-	results := make([]tx.RequestResponse, 0, len(t.ops))
+	succeeded := true
 
-	for _, oper := range t.ops {
+	for _, pred := range t.predicates {
+		if !t.check(pred) {
+			succeeded = false
+			break
+		}
+	}
+
+	ops := t.ops
+	if !succeeded {
+		ops = t.elseOps
+	}
+
+	results := make([]tx.RequestResponse, 0, len(ops))
+
+	for _, oper := range ops {
 		switch oper.Type() {
 		case operation.TypeGet:
 			kvs := t.executeGet(oper.Key())
@@ -194,11 +213,58 @@ func (t *mockTx) Commit() (tx.Response, error) {
 	}
 
 	return tx.Response{
-		Succeeded: true,
+		Succeeded: succeeded,
 		Results:   results,
 	}, nil
 }
 
+// check evaluates a predicate against the stored data. A missing key has
+// version 0 and no value.
+func (t *mockTx) check(pred predicate.Predicate) bool {
+	entry, ok := t.storage.data[string(pred.Key())]
+
+	var order int
+
+	switch pred.Target() {
+	case predicate.TargetVersion:
+		version, _ := pred.Value().(int64)
+		order = cmp.Compare(entry.ModRevision, version)
+	case predicate.TargetValue:
+		if !ok {
+			return pred.Operation() == predicate.OpNotEqual
+		}
+
+		order = bytes.Compare(entry.Value, predicateBytes(pred.Value()))
+	default:
+		return false
+	}
+
+	switch pred.Operation() {
+	case predicate.OpEqual:
+		return order == 0
+	case predicate.OpNotEqual:
+		return order != 0
+	case predicate.OpGreater:
+		return order > 0
+	case predicate.OpLess:
+		return order < 0
+	default:
+		return false
+	}
+}
+
+// predicateBytes converts the value of a value predicate to bytes.
+func predicateBytes(value any) []byte {
+	switch v := value.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return nil
+	}
+}
+
 // executeGet retrieves key-value pairs matching the given key.
 // If the key ends with "/", it performs a prefix match.
 func (t *mockTx) executeGet(key []byte) []kv.KeyValue {
@@ -10,6 +10,7 @@ import (
 	"github.com/tarantool/go-config/internal/testutil"
 	"github.com/tarantool/go-storage/kv"
 	"github.com/tarantool/go-storage/operation"
+	"github.com/tarantool/go-storage/predicate"
 )
 
 func TestMockStorage_Put_And_Get(t *testing.T) {
@@ -118,3 +119,37 @@ func TestMockStorage_TxPut(t *testing.T) {
 	require.Len(t, resp.Results[0].Values, 1)
 	assert.Equal(t, []byte("value"), resp.Results[0].Values[0].Value)
 }
+
+func TestMockStorage_TxPredicates(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage().Put([]byte("/key"), []byte("value"))
+
+	ctx := context.Background()
+	resp, err := mock.Tx(ctx).
+		If(predicate.VersionEqual([]byte("/key"), 1), predicate.VersionEqual([]byte("/new"), 0)).
+		Then(operation.Put([]byte("/new"), []byte("then"))).
+		Else(operation.Put([]byte("/new"), []byte("else"))).
+		Commit()
+	require.NoError(t, err)
+	assert.True(t, resp.Succeeded)
+
+	resp, err = mock.Tx(ctx).
+		If(predicate.ValueEqual([]byte("/key"), []byte("other"))).
+		Then(operation.Delete([]byte("/key"))).
+		Else(operation.Get([]byte("/new"))).
+		Commit()
+	require.NoError(t, err)
+	assert.False(t, resp.Succeeded)
+	require.Len(t, resp.Results, 1)
+	require.Len(t, resp.Results[0].Values, 1)
+	assert.Equal(t, []byte("then"), resp.Results[0].Values[0].Value)
+
+	resp, err = mock.Tx(ctx).
+		If(predicate.VersionGreater([]byte("/key"), 1)).
+		Then(operation.Delete([]byte("/key"))).
+		Commit()
+	require.NoError(t, err)
+	assert.False(t, resp.Succeeded)
+	assert.Empty(t, resp.Results)
+}
@@ -0,0 +1,383 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+)
+
+// Interpolation reference schemes handled out of the box.
+const (
+	// InterpolationEnv expands ${env:NAME} to the value of an environment
+	// variable.
+	InterpolationEnv = "env"
+	// InterpolationFile expands ${file:/path} to the contents of a file,
+	// without a single trailing newline.
+	InterpolationFile = "file"
+	// InterpolationPath expands ${path:other/key} to the (expanded) value of
+	// another configuration key. It cannot be overridden.
+	InterpolationPath = "path"
+)
+
+// InterpolationResolver resolves the reference part of ${scheme:reference}
+// to its replacement text.
+type InterpolationResolver func(ref string) (string, error)
+
+// InterpolationOption configures [Builder.WithInterpolation].
+type InterpolationOption func(*interpolationConfig)
+
+// WithInterpolationResolver registers a resolver for ${scheme:...}
+// references, replacing the built-in one for the same scheme ("env" or
+// "file"). Useful to plug in a secret store, or to resolve environment
+// variables from somewhere other than the process environment. The "path"
+// scheme is reserved and cannot be replaced.
+func WithInterpolationResolver(scheme string, resolver InterpolationResolver) InterpolationOption {
+	return func(cfg *interpolationConfig) {
+		if scheme == InterpolationPath || resolver == nil {
+			return
+		}
+
+		cfg.resolvers[scheme] = resolver
+	}
+}
+
+// interpolationConfig holds the settings of the interpolation stage.
+type interpolationConfig struct {
+	resolvers map[string]InterpolationResolver
+}
+
+func newInterpolationConfig(opts []InterpolationOption) *interpolationConfig {
+	cfg := &interpolationConfig{
+		resolvers: map[string]InterpolationResolver{
+			InterpolationEnv:  resolveEnv,
+			InterpolationFile: resolveFile,
+		},
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	return cfg
+}
+
+// resolveEnv is the built-in resolver of ${env:NAME}.
+func resolveEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable is not set", ErrUnresolvedReference)
+	}
+
+	return value, nil
+}
+
+// resolveFile is the built-in resolver of ${file:/path}.
+func resolveFile(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnresolvedReference, err)
+	}
+
+	content := strings.TrimSuffix(string(data), "\n")
+	content = strings.TrimSuffix(content, "\r")
+
+	return content, nil
+}
+
+// InterpolationError reports a reference that could not be expanded during
+// Build. It carries the location of the value that holds the reference.
+type InterpolationError struct {
+	// Path is the key that holds the reference.
+	Path KeyPath
+	// Source is the name of the collector the value came from.
+	Source string
+	// Range is the position of the value in its source, if known.
+	Range tree.Range
+	// Err describes the failure; it wraps [ErrUnresolvedReference],
+	// [ErrReferenceCycle] or [ErrInvalidReference].
+	Err error
+}
+
+func (e *InterpolationError) Error() string {
+	location := e.Source
+	if e.Range.Start.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, e.Range.Start.Line, e.Range.Start.Column)
+	}
+
+	if location == "" {
+		return fmt.Sprintf("interpolate %s: %v", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("interpolate %s (%s): %v", e.Path, location, e.Err)
+}
+
+func (e *InterpolationError) Unwrap() error {
+	return e.Err
+}
+
+// errFailedReference is returned when a value refers to a key whose own
+// expansion failed; the failure is reported at that key only.
+var errFailedReference = errors.New("referenced key failed to expand")
+
+// cycleError is a reference cycle, propagated up to the key that starts it.
+type cycleError struct {
+	chain []keypath.KeyPath
+}
+
+func (e *cycleError) Error() string {
+	parts := make([]string, 0, len(e.chain))
+	for _, path := range e.chain {
+		parts = append(parts, path.String())
+	}
+
+	return fmt.Sprintf("%v: %s", ErrReferenceCycle, strings.Join(parts, " -> "))
+}
+
+func (e *cycleError) Unwrap() error {
+	return ErrReferenceCycle
+}
+
+// interpolationState is the expansion state of a single tree node.
+type interpolationState struct {
+	done  bool
+	value any
+	err   error
+}
+
+// interpolator expands references in the string leaves of a merged tree.
+type interpolator struct {
+	cfg   *interpolationConfig
+	root  *tree.Node
+	state map[*tree.Node]*interpolationState
+	stack []keypath.KeyPath
+	errs  []error
+}
+
+// interpolate expands the references in the merged root and in every layer.
+// References to other keys (${path:...}) always resolve against the merged
+// root. Only failures in the merged root are reported: a failing value in a
+// layer has been overridden and is left unexpanded.
+func interpolate(cfg *interpolationConfig, root *tree.Node, layers []*tree.Node) []error {
+	interp := &interpolator{
+		cfg:   cfg,
+		root:  root,
+		state: make(map[*tree.Node]*interpolationState),
+		stack: nil,
+		errs:  nil,
+	}
+
+	walkStringLeaves(root, nil, func(node *tree.Node, path keypath.KeyPath) {
+		_, _ = interp.resolve(node, path)
+	})
+
+	for _, layer := range layers {
+		walkStringLeaves(layer, nil, func(node *tree.Node, _ keypath.KeyPath) {
+			raw, _ := node.Value.(string)
+
+			value, err := interp.expand(raw)
+			if err == nil && value != raw {
+				node.SetUnexpanded(raw)
+				node.Value = value
+			}
+		})
+	}
+
+	return interp.errs
+}
+
+// walkStringLeaves calls visit for every leaf of node that holds a string
+// containing a reference.
+func walkStringLeaves(node *tree.Node, path keypath.KeyPath, visit func(*tree.Node, keypath.KeyPath)) {
+	if node == nil {
+		return
+	}
+
+	if node.IsLeaf() {
+		if str, ok := node.Value.(string); ok && strings.Contains(str, "${") {
+			visit(node, path)
+		}
+
+		return
+	}
+
+	for _, key := range node.ChildrenKeys() {
+		walkStringLeaves(node.Child(key), path.Append(key), visit)
+	}
+}
+
+// resolve expands the value of node, located at path, once; later calls
+// return the memoized result. Failures are recorded as InterpolationErrors
+// at the key where they originate.
+func (interp *interpolator) resolve(node *tree.Node, path keypath.KeyPath) (any, error) {
+	if state, ok := interp.state[node]; ok {
+		if !state.done {
+			return nil, interp.cycle(path)
+		}
+
+		return state.value, state.err
+	}
+
+	raw, ok := node.Value.(string)
+	if !ok {
+		return node.Value, nil
+	}
+
+	state := &interpolationState{done: false, value: nil, err: nil}
+	interp.state[node] = state
+	interp.stack = append(interp.stack, path)
+
+	value, err := interp.expand(raw)
+
+	interp.stack = interp.stack[:len(interp.stack)-1]
+	state.done = true
+
+	if err != nil {
+		state.err = errFailedReference
+
+		var cycleErr *cycleError
+		if errors.As(err, &cycleErr) && !cycleErr.chain[0].Equals(path) {
+			// Report the cycle once, at the key that starts it.
+			state.err = err
+
+			return nil, err
+		}
+
+		if !errors.Is(err, errFailedReference) {
+			interp.errs = append(interp.errs, &InterpolationError{
+				Path:   path,
+				Source: node.Source,
+				Range:  node.Range,
+				Err:    err,
+			})
+		}
+
+		return nil, state.err
+	}
+
+	state.value = value
+
+	if value != raw {
+		node.SetUnexpanded(raw)
+		node.Value = value
+	}
+
+	return value, nil
+}
+
+// cycle builds the error for a reference back to path, which is being
+// expanded higher up the stack.
+func (interp *interpolator) cycle(path keypath.KeyPath) error {
+	start := 0
+
+	for i, visiting := range interp.stack {
+		if visiting.Equals(path) {
+			start = i
+
+			break
+		}
+	}
+
+	chain := append(append([]keypath.KeyPath{}, interp.stack[start:]...), path)
+
+	return &cycleError{chain: chain}
+}
+
+// expand replaces the references in raw. A value that consists of a single
+// ${path:...} reference takes the referenced value as is, keeping its type;
+// otherwise references are substituted as text. "$${" yields a literal "${".
+func (interp *interpolator) expand(raw string) (any, error) {
+	var (
+		builder strings.Builder
+		rest    = raw
+	)
+
+	for {
+		idx := strings.Index(rest, "${")
+		if idx < 0 {
+			builder.WriteString(rest)
+
+			break
+		}
+
+		if idx > 0 && rest[idx-1] == '$' {
+			builder.WriteString(rest[:idx-1])
+			builder.WriteString("${")
+
+			rest = rest[idx+len("${"):]
+
+			continue
+		}
+
+		builder.WriteString(rest[:idx])
+
+		end := strings.IndexByte(rest[idx:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated %q", ErrInvalidReference, rest[idx:])
+		}
+
+		ref := rest[idx+len("${") : idx+end]
+		whole := idx == 0 && idx+end+1 == len(rest) && builder.Len() == 0
+
+		value, err := interp.resolveRef(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if whole {
+			return value, nil
+		}
+
+		fmt.Fprint(&builder, value)
+
+		rest = rest[idx+end+1:]
+	}
+
+	return builder.String(), nil
+}
+
+// resolveRef resolves a single "scheme:reference" found inside ${...}.
+func (interp *interpolator) resolveRef(ref string) (any, error) {
+	scheme, name, ok := strings.Cut(ref, ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%w: ${%s}", ErrInvalidReference, ref)
+	}
+
+	if scheme == InterpolationPath {
+		return interp.resolvePath(name)
+	}
+
+	resolver, ok := interp.cfg.resolvers[scheme]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown scheme in ${%s}", ErrInvalidReference, ref)
+	}
+
+	value, err := resolver(name)
+	if err != nil {
+		return nil, fmt.Errorf("${%s}: %w", ref, err)
+	}
+
+	return value, nil
+}
+
+// resolvePath resolves ${path:...} to the expanded value of another key.
+func (interp *interpolator) resolvePath(name string) (any, error) {
+	path := keypath.NewKeyPath(name)
+
+	target := interp.root.Get(path)
+	if target == nil {
+		return nil, fmt.Errorf("${path:%s}: %w: %w", name, ErrUnresolvedReference, ErrKeyNotFound)
+	}
+
+	if !target.IsLeaf() || target.IsArray() {
+		return nil, fmt.Errorf("%w: ${path:%s} refers to a map or an array", ErrInvalidReference, name)
+	}
+
+	return interp.resolve(target, path)
+}
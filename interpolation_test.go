@@ -0,0 +1,199 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/tree"
+)
+
+// fakeEnv is an interpolation resolver backed by a map, so that tests do not
+// depend on the process environment.
+func fakeEnv(vars map[string]string) config.InterpolationOption {
+	return config.WithInterpolationResolver(config.InterpolationEnv, func(name string) (string, error) {
+		value, ok := vars[name]
+		if !ok {
+			return "", config.ErrUnresolvedReference
+		}
+
+		return value, nil
+	})
+}
+
+func buildInterpolated(t *testing.T, data string, opts ...config.InterpolationOption) (config.Config, []error) {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	col, err := collectors.NewSource(t.Context(), collectors.NewFile(path), collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+	builder = builder.WithInterpolation(opts...)
+
+	return builder.Build(t.Context())
+}
+
+func TestBuilder_WithInterpolation(t *testing.T) {
+	t.Parallel()
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0o600))
+
+	cfg, errs := buildInterpolated(t, `
+defaults:
+  port: 3301
+  host: ${env:HOST}
+server:
+  url: http://${path:defaults/host}:${path:defaults/port}/
+  port: ${path:defaults/port}
+  password: ${file:`+secret+`}
+  literal: $${env:HOST}
+`, fakeEnv(map[string]string{"HOST": "db.local"}))
+	require.Empty(t, errs)
+
+	url, err := config.GetAs[string](&cfg, config.NewKeyPath("server/url"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://db.local:3301/", url)
+
+	var port any
+
+	_, err = cfg.Get(config.NewKeyPath("server/port"), &port)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3301), port, "a whole-value reference keeps the type")
+
+	assert.Equal(t, "s3cr3t", config.MustGet[string](&cfg, config.NewKeyPath("server/password")))
+	assert.Equal(t, "${env:HOST}", config.MustGet[string](&cfg, config.NewKeyPath("server/literal")))
+}
+
+func TestBuilder_WithInterpolation_MarshalKeepsReferences(t *testing.T) {
+	t.Parallel()
+
+	input := "db:\n  user: admin\n  password: ${env:DB_PASSWORD}\n"
+
+	cfg, errs := buildInterpolated(t, input, fakeEnv(map[string]string{"DB_PASSWORD": "hunter2"}))
+	require.Empty(t, errs)
+
+	assert.Equal(t, "hunter2", config.MustGet[string](&cfg, config.NewKeyPath("db/password")))
+
+	out, err := cfg.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, input, string(out))
+}
+
+func TestBuilder_WithInterpolation_MapCollectorMarshal(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"token": "${env:TOKEN}"}))
+	builder = builder.WithInterpolation(fakeEnv(map[string]string{"TOKEN": "abc"}))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	assert.Equal(t, "abc", config.MustGet[string](&cfg, config.NewKeyPath("token")))
+
+	out, err := cfg.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, "token: ${env:TOKEN}\n", string(out))
+}
+
+func TestBuilder_WithInterpolation_Unresolved(t *testing.T) {
+	t.Parallel()
+
+	_, errs := buildInterpolated(t, "a: 1\nb: ${env:MISSING}\nc: ${path:b}\nd: ${path:nope}\n", fakeEnv(nil))
+	require.Len(t, errs, 2, "c depends on b and is not reported separately")
+
+	var interpErr *config.InterpolationError
+	require.ErrorAs(t, errs[0], &interpErr)
+	require.ErrorIs(t, errs[0], config.ErrUnresolvedReference)
+	assert.Equal(t, config.NewKeyPath("b"), interpErr.Path)
+	assert.Equal(t, tree.NewRange(2, 4, 2, 4), interpErr.Range)
+	assert.Contains(t, errs[0].Error(), "${env:MISSING}")
+
+	require.ErrorAs(t, errs[1], &interpErr)
+	require.ErrorIs(t, errs[1], config.ErrKeyNotFound)
+	assert.Equal(t, config.NewKeyPath("d"), interpErr.Path)
+}
+
+func TestBuilder_WithInterpolation_Cycle(t *testing.T) {
+	t.Parallel()
+
+	_, errs := buildInterpolated(t, "a: ${path:b}\nb: x-${path:c}\nc: ${path:a}\nd: ${path:d}\n")
+	require.Len(t, errs, 2)
+
+	var interpErr *config.InterpolationError
+	require.ErrorAs(t, errs[0], &interpErr)
+	require.ErrorIs(t, errs[0], config.ErrReferenceCycle)
+	assert.Equal(t, config.NewKeyPath("a"), interpErr.Path)
+	assert.Equal(t, tree.NewRange(1, 4, 1, 4), interpErr.Range)
+	assert.Contains(t, errs[0].Error(), "a -> b -> c -> a")
+
+	require.ErrorIs(t, errs[1], config.ErrReferenceCycle)
+	assert.Contains(t, errs[1].Error(), "d -> d")
+}
+
+func TestBuilder_WithInterpolation_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"${env:X", "${nocolon}", "${vault:x}", "${path:m}"} {
+		_, errs := buildInterpolated(t, "m:\n  k: v\nv: '"+value+"'\n")
+		require.Len(t, errs, 1, value)
+		require.ErrorIs(t, errs[0], config.ErrInvalidReference, value)
+	}
+}
+
+func TestBuilder_WithInterpolation_CustomScheme(t *testing.T) {
+	t.Parallel()
+
+	cfg, errs := buildInterpolated(t, "key: ${vault:db/key}\n",
+		config.WithInterpolationResolver("vault", func(ref string) (string, error) {
+			return "from-vault:" + ref, nil
+		}))
+	require.Empty(t, errs)
+	assert.Equal(t, "from-vault:db/key", config.MustGet[string](&cfg, config.NewKeyPath("key")))
+}
+
+func TestBuilder_WithInterpolation_Inheritance(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"credentials": map[string]any{"password": "${env:PASSWORD}"},
+		"groups": map[string]any{
+			"g": map[string]any{
+				"replicasets": map[string]any{
+					"r": map[string]any{
+						"instances": map[string]any{"i": map[string]any{"port": 3301}},
+					},
+				},
+			},
+		},
+	}))
+	builder = builder.WithInheritance(config.Levels(config.Global, "groups", "replicasets", "instances"))
+	builder = builder.WithInterpolation(fakeEnv(map[string]string{"PASSWORD": "secret"}))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	effective, err := cfg.Effective(config.NewKeyPath("groups/g/replicasets/r/instances/i"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", config.MustGet[string](&effective, config.NewKeyPath("credentials/password")))
+}
+
+func TestBuilder_WithInterpolation_Env(t *testing.T) {
+	t.Setenv("GO_CONFIG_INTERPOLATION_TEST", "from-env")
+
+	cfg, errs := buildInterpolated(t, "value: ${env:GO_CONFIG_INTERPOLATION_TEST}\n")
+	require.Empty(t, errs)
+	assert.Equal(t, "from-env", config.MustGet[string](&cfg, config.NewKeyPath("value")))
+}
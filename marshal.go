@@ -2,15 +2,23 @@ package config
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/tarantool/go-config/keypath"
 	"github.com/tarantool/go-config/meta"
 	"github.com/tarantool/go-config/tree"
 	"go.yaml.in/yaml/v3"
 )
 
+// jsonIndent is the indentation of canonical JSON.
+const jsonIndent = "  "
+
 const (
 	yamlIndent    = 2
 	yamlFloatTag  = "!!float"
@@ -46,13 +54,33 @@ func (c *Config) String() string {
 // Key insertion order is preserved across maps. For nodes that came from a
 // YAML source and have not been mutated, the original scalar style and the
 // surrounding head/line/foot comments are preserved. Programmatically-added
-// keys are emitted with default style and no comments.
+// keys are emitted with default style and no comments. Values expanded by
+// interpolation (see [Builder.WithInterpolation]) are written unexpanded.
+// Values of sensitive keys (see [Builder.WithSensitive]) are replaced with
+// [MaskedValue].
 func (c *Config) MarshalYAML() ([]byte, error) {
-	if c.root == nil || c.root.IsLeaf() && c.root.Value == nil && len(c.root.ChildrenKeys()) == 0 {
+	return c.marshalYAML(treeMarshaler{sensitive: c.sensitive, canonical: false})
+}
+
+// MarshalCanonicalYAML serializes the Config as canonical YAML: keys are
+// sorted, comments and source styles are dropped, and scalars are normalized
+// (every integer type is written the same way, an integral float as an
+// integer, a time.Duration as "1m30s", a time.Time as RFC 3339 in UTC). Two
+// configs with equal contents serialize byte-identically regardless of key
+// order and source formatting, which makes the output suitable for storing
+// and diffing. Interpolation and sensitive keys are handled as in
+// [Config.MarshalYAML].
+func (c *Config) MarshalCanonicalYAML() ([]byte, error) {
+	return c.marshalYAML(treeMarshaler{sensitive: c.sensitive, canonical: true})
+}
+
+// marshalYAML serializes the Config as YAML with the given marshaler.
+func (c *Config) marshalYAML(marshaler treeMarshaler) ([]byte, error) {
+	if isEmptyRoot(c.root) {
 		return []byte{}, nil
 	}
 
-	yamlNode, err := nodeToYAML(c.root)
+	yamlNode, err := marshaler.nodeToYAML(c.root, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -90,38 +118,133 @@ func yamlAnnotation(node *tree.Node) YAMLAnnotation {
 	return annotation
 }
 
-// nodeToYAML converts a tree.Node into a *yaml.Node.
+// MarshalJSON serializes the Config as JSON. It implements json.Marshaler.
+//
+// Key insertion order is preserved across objects and array nodes are
+// written as JSON arrays. An empty Config is written as "{}". As with
+// [Config.MarshalYAML], interpolated values are written unexpanded and
+// sensitive values are masked. NaN and infinite floats cannot be represented
+// in JSON and fail the call.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return c.marshalJSON(treeMarshaler{sensitive: c.sensitive, canonical: false})
+}
+
+// MarshalCanonicalJSON serializes the Config as canonical JSON, indented by
+// two spaces and terminated by a newline. Keys are sorted and scalars are
+// normalized as in [Config.MarshalCanonicalYAML].
+func (c *Config) MarshalCanonicalJSON() ([]byte, error) {
+	out, err := c.marshalJSON(treeMarshaler{sensitive: c.sensitive, canonical: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = json.Indent(&buf, out, "", jsonIndent)
+	if err != nil {
+		return nil, fmt.Errorf("json indent: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// marshalJSON serializes the Config as compact JSON with the given marshaler.
+func (c *Config) marshalJSON(marshaler treeMarshaler) ([]byte, error) {
+	if isEmptyRoot(c.root) {
+		return []byte("{}"), nil
+	}
+
+	var buf bytes.Buffer
+
+	err := marshaler.writeJSON(&buf, c.root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// isEmptyRoot reports whether root holds no configuration at all.
+func isEmptyRoot(root *tree.Node) bool {
+	return root == nil || root.IsLeaf() && root.Value == nil && len(root.ChildrenKeys()) == 0
+}
+
+// treeMarshaler holds the settings of a single marshal call.
+type treeMarshaler struct {
+	// sensitive are the patterns of keys whose values are masked.
+	sensitive []keypath.KeyPath
+	// canonical sorts keys, normalizes scalars and drops source styles and
+	// comments.
+	canonical bool
+}
+
+// leafValue returns the value to write for the leaf node at path: the
+// unexpanded source of an interpolated value, masked if sensitive and
+// normalized in canonical mode.
+func (m treeMarshaler) leafValue(node *tree.Node, path keypath.KeyPath) any {
+	// Write back interpolation references rather than their expansions, so
+	// that a round-tripped file does not leak the expanded values.
+	value := node.Value
+	if unexpanded, ok := node.Unexpanded(); ok && node.Source != meta.ModifiedSourceName {
+		value = unexpanded
+	}
+
+	value = maskSensitive(m.sensitive, path, value)
+
+	if m.canonical {
+		value = canonicalValue(value)
+	}
+
+	return value
+}
+
+// childKeys returns the keys of a mapping node in output order.
+func (m treeMarshaler) childKeys(node *tree.Node) []string {
+	keys := node.ChildrenKeys()
+	if m.canonical {
+		slices.Sort(keys)
+	}
+
+	return keys
+}
+
+// nodeToYAML converts a tree.Node located at path into a *yaml.Node.
 // When the tree node was unchanged since parsing from YAML, the original
 // scalar style and surrounding comments are reused.
-func nodeToYAML(node *tree.Node) (*yaml.Node, error) {
+func (m treeMarshaler) nodeToYAML(node *tree.Node, path keypath.KeyPath) (*yaml.Node, error) {
 	switch {
 	case node == nil:
 		return newScalarNode(yamlNullTag, ""), nil
 	case node.IsArray():
-		return arrayNodeToYAML(node)
+		return m.arrayNodeToYAML(node, path)
 	case node.IsLeaf():
-		return scalarNodeToYAML(node)
+		return m.scalarNodeToYAML(node, path)
 	default:
-		return mappingNodeToYAML(node)
+		return m.mappingNodeToYAML(node, path)
 	}
 }
 
 // scalarNodeToYAML emits a leaf as a scalar yaml.Node, reusing style/comments
 // from the original annotation when the value has not been modified.
-func scalarNodeToYAML(node *tree.Node) (*yaml.Node, error) {
+func (m treeMarshaler) scalarNodeToYAML(node *tree.Node, path keypath.KeyPath) (*yaml.Node, error) {
 	annotation := yamlAnnotation(node)
 	mutated := node.Source == meta.ModifiedSourceName
+	masked := matchesAny(path, m.sensitive)
 
-	if annotation.Val != nil && !mutated {
+	if annotation.Val != nil && !mutated && !masked && !m.canonical {
 		clone := cloneScalarYAMLNode(annotation.Val)
 		forcePlainStringQuoting(clone)
 
 		return clone, nil
 	}
 
+	value := m.leafValue(node, path)
+
 	out := newScalarNode("", "")
 
-	encErr := out.Encode(node.Value)
+	encErr := out.Encode(value)
 	if encErr != nil {
 		return nil, fmt.Errorf("encode scalar: %w", encErr)
 	}
@@ -130,11 +253,11 @@ func scalarNodeToYAML(node *tree.Node) (*yaml.Node, error) {
 	// Value for Inf and NaN — the fallback below ensures we always emit
 	// the canonical YAML form for those special floats.
 	if out.Kind == yaml.ScalarNode && out.Value == "" {
-		fillSpecialFloat(out, node.Value)
+		fillSpecialFloat(out, value)
 	}
 
 	// Carry over comments from the annotation even when the value is mutated.
-	if annotation.Val != nil {
+	if annotation.Val != nil && !m.canonical {
 		out.HeadComment = annotation.Val.HeadComment
 		out.LineComment = annotation.Val.LineComment
 		out.FootComment = annotation.Val.FootComment
@@ -197,25 +320,28 @@ func fillSpecialFloat(out *yaml.Node, value any) {
 }
 
 // mappingNodeToYAML emits a mapping yaml.Node, walking children in tree order.
-func mappingNodeToYAML(node *tree.Node) (*yaml.Node, error) {
+func (m treeMarshaler) mappingNodeToYAML(node *tree.Node, path keypath.KeyPath) (*yaml.Node, error) {
 	out := newCollectionNode(yaml.MappingNode)
 
-	if annotation := yamlAnnotation(node); annotation.Val != nil {
+	if annotation := yamlAnnotation(node); annotation.Val != nil && !m.canonical {
 		out.Style = annotation.Val.Style
 		out.HeadComment = annotation.Val.HeadComment
 		out.LineComment = annotation.Val.LineComment
 		out.FootComment = annotation.Val.FootComment
 	}
 
-	for _, key := range node.ChildrenKeys() {
+	for _, key := range m.childKeys(node) {
 		child := node.Child(key)
 		if child == nil {
 			continue
 		}
 
-		keyNode := keyYAMLNode(key, child)
+		keyNode := newScalarNode(yamlStringTag, key)
+		if !m.canonical {
+			keyNode = keyYAMLNode(key, child)
+		}
 
-		valNode, err := nodeToYAML(child)
+		valNode, err := m.nodeToYAML(child, path.Append(key))
 		if err != nil {
 			return nil, err
 		}
@@ -227,10 +353,10 @@ func mappingNodeToYAML(node *tree.Node) (*yaml.Node, error) {
 }
 
 // arrayNodeToYAML emits a sequence yaml.Node, walking children in index order.
-func arrayNodeToYAML(node *tree.Node) (*yaml.Node, error) {
+func (m treeMarshaler) arrayNodeToYAML(node *tree.Node, path keypath.KeyPath) (*yaml.Node, error) {
 	out := newCollectionNode(yaml.SequenceNode)
 
-	if annotation := yamlAnnotation(node); annotation.Val != nil {
+	if annotation := yamlAnnotation(node); annotation.Val != nil && !m.canonical {
 		out.Style = annotation.Val.Style
 		out.HeadComment = annotation.Val.HeadComment
 		out.LineComment = annotation.Val.LineComment
@@ -243,7 +369,7 @@ func arrayNodeToYAML(node *tree.Node) (*yaml.Node, error) {
 			continue
 		}
 
-		valNode, err := nodeToYAML(child)
+		valNode, err := m.nodeToYAML(child, path.Append(key))
 		if err != nil {
 			return nil, err
 		}
@@ -337,3 +463,138 @@ func newCollectionNode(kind yaml.Kind) *yaml.Node {
 		Column:      0,
 	}
 }
+
+// writeJSON writes the tree.Node located at path to buf as compact JSON.
+func (m treeMarshaler) writeJSON(buf *bytes.Buffer, node *tree.Node, path keypath.KeyPath) error {
+	switch {
+	case node == nil:
+		buf.WriteString("null")
+
+		return nil
+	case node.IsArray() && !node.IsLeaf():
+		buf.WriteByte('[')
+
+		for i, key := range orderedArrayKeys(node) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			err := m.writeJSON(buf, node.Child(key), path.Append(key))
+			if err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+
+		return nil
+	case node.IsArray():
+		// An empty array node may hold its slice directly in Value.
+		if node.Value == nil {
+			buf.WriteString("[]")
+
+			return nil
+		}
+
+		return writeJSONValue(buf, m.leafValue(node, path))
+	case node.IsLeaf():
+		return writeJSONValue(buf, m.leafValue(node, path))
+	default:
+		buf.WriteByte('{')
+
+		for i, key := range m.childKeys(node) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			err := writeJSONValue(buf, key)
+			if err != nil {
+				return err
+			}
+
+			buf.WriteByte(':')
+
+			err = m.writeJSON(buf, node.Child(key), path.Append(key))
+			if err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+
+		return nil
+	}
+}
+
+// writeJSONValue writes a Go value to buf as compact JSON, without escaping
+// HTML characters.
+func writeJSONValue(buf *bytes.Buffer, value any) error {
+	var out bytes.Buffer
+
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(value)
+	if err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+
+	buf.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+
+	return nil
+}
+
+// maxExactFloatInt is the largest integer below which every integer is
+// exactly representable as a float64 (2^53).
+const maxExactFloatInt = 1 << 53
+
+// canonicalValue normalizes a leaf value for canonical output: integers of
+// every width become int64 (or uint64 beyond its range), integral floats
+// become integers, time.Duration becomes its string form and time.Time an
+// RFC 3339 string in UTC. Maps and slices are normalized element-wise.
+func canonicalValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typed))
+		for key, child := range typed {
+			result[key] = canonicalValue(child)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typed))
+		for i, child := range typed {
+			result[i] = canonicalValue(child)
+		}
+
+		return result
+	case time.Duration:
+		return typed.String()
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano)
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch {
+	case !rv.IsValid():
+		return nil
+	case rv.CanInt():
+		return rv.Int()
+	case rv.CanUint():
+		if rv.Uint() <= math.MaxInt64 {
+			return int64(rv.Uint())
+		}
+
+		return rv.Uint()
+	case rv.CanFloat():
+		floatVal := rv.Float()
+		if floatVal == math.Trunc(floatVal) && math.Abs(floatVal) < maxExactFloatInt {
+			return int64(floatVal)
+		}
+
+		return floatVal
+	default:
+		return value
+	}
+}
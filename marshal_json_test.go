@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+func TestMarshalJSON_PreservesOrderAndArrays(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromYAML(t, `# comment
+zeta: 1
+alpha:
+  name: "<app>"
+  ports:
+    - 3301
+    - 3302
+  nested:
+    - b: 2
+      a: 1
+  empty: []
+  nothing: null
+flag: true
+ratio: 0.5
+`)
+
+	out, err := cfg.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"zeta":1,"alpha":{"name":"<app>","ports":[3301,3302],`+
+		`"nested":[{"b":2,"a":1}],"empty":[],"nothing":null},"flag":true,"ratio":0.5}`, string(out))
+	assert.Equal(t, `{"zeta":1,"alpha":{"name":"<app>","ports":[3301,3302],`+
+		`"nested":[{"b":2,"a":1}],"empty":[],"nothing":null},"flag":true,"ratio":0.5}`, string(out))
+
+	// Config implements json.Marshaler; encoding/json escapes HTML on top.
+	viaStd, err := json.Marshal(&cfg.Config)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(out), string(viaStd))
+}
+
+func TestMarshalJSON_Empty(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+
+	out, err := cfg.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(out))
+}
+
+func TestMarshalJSON_NaN(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "cfg", map[string]any{"value": math.NaN()})
+
+	_, err := cfg.MarshalJSON()
+	require.Error(t, err)
+}
+
+func TestMarshalJSON_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"db": map[string]any{"password": "p"}}))
+	builder = builder.WithSensitive("db/password")
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	out, err := cfg.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"db":{"password":"******"}}`, string(out))
+}
+
+func TestMarshalCanonical_EqualConfigsAreIdentical(t *testing.T) {
+	t.Parallel()
+
+	fromYAML := buildFromYAML(t, `# A comment that canonical output drops.
+server:
+  port: 3301
+  host: 'localhost'
+  timeout: 1.0
+  tags:
+    - b
+    - a
+labels: {x: "1", a: "2"}
+`)
+
+	fromMap := buildFromMap(t, "map", map[string]any{
+		"labels": map[string]any{"a": "2", "x": "1"},
+		"server": map[string]any{
+			"tags":    []any{"b", "a"},
+			"timeout": uint8(1),
+			"host":    "localhost",
+			"port":    int32(3301),
+		},
+	})
+
+	yamlA, err := fromYAML.MarshalCanonicalYAML()
+	require.NoError(t, err)
+
+	yamlB, err := fromMap.MarshalCanonicalYAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, `labels:
+  a: "2"
+  x: "1"
+server:
+  host: localhost
+  port: 3301
+  tags:
+    - b
+    - a
+  timeout: 1
+`, string(yamlA))
+	assert.Equal(t, string(yamlA), string(yamlB))
+
+	jsonA, err := fromYAML.MarshalCanonicalJSON()
+	require.NoError(t, err)
+
+	jsonB, err := fromMap.MarshalCanonicalJSON()
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "labels": {
+    "a": "2",
+    "x": "1"
+  },
+  "server": {
+    "host": "localhost",
+    "port": 3301,
+    "tags": [
+      "b",
+      "a"
+    ],
+    "timeout": 1
+  }
+}
+`, string(jsonA))
+	assert.Equal(t, string(jsonA), string(jsonB))
+}
+
+func TestMarshalCanonical_NormalizesScalars(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromMap(t, "map", map[string]any{
+		"duration": 90 * time.Second,
+		"time":     time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("X", 3600)),
+		"float":    float32(0.5),
+		"big":      uint64(math.MaxUint64),
+		"nested":   map[string]any{"z": int16(1), "a": []any{2.0}},
+	})
+
+	out, err := cfg.MarshalCanonicalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"big": 18446744073709551615,
+		"duration": "1m30s",
+		"float": 0.5,
+		"nested": {"a": [2], "z": 1},
+		"time": "2024-05-01T11:00:00Z"
+	}`, string(out))
+}
@@ -0,0 +1,422 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+)
+
+// MarshalTOML serializes the Config as a TOML document.
+//
+// Within every table the plain keys come first, in insertion order, followed
+// by the sub-tables ([table]) and the arrays of tables ([[table]]), also in
+// insertion order. An array whose elements are all maps is written as an
+// array of tables; other arrays are written inline. A time.Time is written
+// as an offset date-time and a time.Duration as a string. TOML has no null:
+// keys with a nil value are omitted. As with [Config.MarshalYAML],
+// interpolated values are written unexpanded and sensitive values are
+// masked.
+//
+// The error wraps [ErrTOMLUnsupported] if the configuration cannot be
+// represented in TOML: the root is not a map, an array holds a nil value or
+// an integer overflows int64.
+func (c *Config) MarshalTOML() ([]byte, error) {
+	if isEmptyRoot(c.root) {
+		return []byte{}, nil
+	}
+
+	if c.root.IsLeaf() || c.root.IsArray() {
+		return nil, fmt.Errorf("%w: the root must be a map", ErrTOMLUnsupported)
+	}
+
+	var buf bytes.Buffer
+
+	writer := tomlWriter{
+		marshaler: treeMarshaler{sensitive: c.sensitive, canonical: false},
+		buf:       &buf,
+	}
+
+	err := writer.writeTable(c.root, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// tomlWriter writes a tree as a TOML document.
+type tomlWriter struct {
+	marshaler treeMarshaler
+	buf       *bytes.Buffer
+}
+
+// writeTable writes the table node located at path. header holds the TOML
+// keys of the table, without array indices; element tells that the table is
+// an element of an array of tables.
+func (w tomlWriter) writeTable(node *tree.Node, path keypath.KeyPath, header []string, element bool) error {
+	var values, tables []string
+
+	for _, key := range node.ChildrenKeys() {
+		child := node.Child(key)
+
+		switch {
+		case child == nil:
+		case isTOMLTable(child) || isTOMLTableArray(child):
+			tables = append(tables, key)
+		default:
+			values = append(values, key)
+		}
+	}
+
+	if len(header) > 0 && (element || len(values) > 0 || len(tables) == 0) {
+		w.writeHeader(header, element)
+	}
+
+	for _, key := range values {
+		err := w.writeKeyValue(node.Child(key), path.Append(key), key)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range tables {
+		child := node.Child(key)
+		childHeader := append(slices.Clip(header), key)
+
+		if !isTOMLTableArray(child) {
+			err := w.writeTable(child, path.Append(key), childHeader, false)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, index := range orderedArrayKeys(child) {
+			err := w.writeTable(child.Child(index), path.Append(key, index), childHeader, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeHeader writes a [table] or [[table]] header, preceded by a blank line.
+func (w tomlWriter) writeHeader(header []string, element bool) {
+	if w.buf.Len() > 0 {
+		w.buf.WriteByte('\n')
+	}
+
+	open, closing := "[", "]"
+	if element {
+		open, closing = "[[", "]]"
+	}
+
+	w.buf.WriteString(open)
+	w.buf.WriteString(tomlDottedKey(header))
+	w.buf.WriteString(closing)
+	w.buf.WriteByte('\n')
+}
+
+// writeKeyValue writes "key = value" for a leaf or an inline array node.
+// Keys with a nil value are skipped.
+func (w tomlWriter) writeKeyValue(node *tree.Node, path keypath.KeyPath, key string) error {
+	var out strings.Builder
+
+	written, err := w.writeInline(&out, node, path)
+	if err != nil || !written {
+		return err
+	}
+
+	w.buf.WriteString(tomlKey(key))
+	w.buf.WriteString(" = ")
+	w.buf.WriteString(out.String())
+	w.buf.WriteByte('\n')
+
+	return nil
+}
+
+// writeInline writes node as an inline TOML value and reports whether there
+// was anything to write.
+func (w tomlWriter) writeInline(out *strings.Builder, node *tree.Node, path keypath.KeyPath) (bool, error) {
+	switch {
+	case node.IsArray() && !node.IsLeaf():
+		out.WriteByte('[')
+
+		for i, key := range orderedArrayKeys(node) {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+
+			written, err := w.writeInline(out, node.Child(key), path.Append(key))
+			if err != nil {
+				return false, err
+			}
+
+			if !written {
+				return false, fmt.Errorf("%w: nil array element at %s", ErrTOMLUnsupported, path.Append(key))
+			}
+		}
+
+		out.WriteByte(']')
+
+		return true, nil
+	case node.IsArray() && node.Value == nil:
+		out.WriteString("[]")
+
+		return true, nil
+	case node.IsLeaf():
+		value := w.marshaler.leafValue(node, path)
+		if value == nil {
+			return false, nil
+		}
+
+		return true, writeTOMLValue(out, value, path)
+	default:
+		out.WriteByte('{')
+
+		for i, key := range node.ChildrenKeys() {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+
+			out.WriteString(tomlKey(key))
+			out.WriteString(" = ")
+
+			written, err := w.writeInline(out, node.Child(key), path.Append(key))
+			if err != nil {
+				return false, err
+			}
+
+			if !written {
+				return false, fmt.Errorf("%w: nil value at %s", ErrTOMLUnsupported, path.Append(key))
+			}
+		}
+
+		out.WriteByte('}')
+
+		return true, nil
+	}
+}
+
+// isTOMLTable reports whether node is written as a [table]: a map node with
+// children.
+func isTOMLTable(node *tree.Node) bool {
+	return !node.IsArray() && !node.IsLeaf()
+}
+
+// isTOMLTableArray reports whether node is written as an array of tables: a
+// non-empty array whose elements are all map nodes.
+func isTOMLTableArray(node *tree.Node) bool {
+	if !node.IsArray() || node.IsLeaf() {
+		return false
+	}
+
+	for _, child := range node.Children() {
+		if child == nil || child.IsArray() {
+			return false
+		}
+
+		if _, ok := child.Value.(map[string]any); !ok && child.IsLeaf() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeTOMLValue writes a Go value located at path as an inline TOML value.
+func writeTOMLValue(out *strings.Builder, value any, path keypath.KeyPath) error {
+	switch typed := value.(type) {
+	case string:
+		out.WriteString(tomlString(typed))
+	case bool:
+		out.WriteString(strconv.FormatBool(typed))
+	case time.Time:
+		out.WriteString(typed.Format(time.RFC3339Nano))
+	case time.Duration:
+		out.WriteString(tomlString(typed.String()))
+	case []any:
+		return writeTOMLArray(out, typed, path)
+	case map[string]any:
+		return writeTOMLInlineTable(out, typed, path)
+	default:
+		return writeTOMLNumber(out, value, path)
+	}
+
+	return nil
+}
+
+// writeTOMLNumber writes an integer or a float. Values of any other type are
+// written as strings.
+func writeTOMLNumber(out *strings.Builder, value any, path keypath.KeyPath) error {
+	rv := reflect.ValueOf(value)
+
+	switch {
+	case rv.CanInt():
+		out.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case rv.CanUint():
+		if rv.Uint() > math.MaxInt64 {
+			return fmt.Errorf("%w: integer %d at %s overflows int64", ErrTOMLUnsupported, rv.Uint(), path)
+		}
+
+		out.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case rv.CanFloat():
+		out.WriteString(tomlFloat(rv.Float()))
+	default:
+		out.WriteString(tomlString(fmt.Sprint(value)))
+	}
+
+	return nil
+}
+
+// writeTOMLArray writes a []any leaf value as an inline array.
+func writeTOMLArray(out *strings.Builder, items []any, path keypath.KeyPath) error {
+	out.WriteByte('[')
+
+	for i, item := range items {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+
+		itemPath := path.Append(strconv.Itoa(i))
+		if item == nil {
+			return fmt.Errorf("%w: nil array element at %s", ErrTOMLUnsupported, itemPath)
+		}
+
+		err := writeTOMLValue(out, item, itemPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	out.WriteByte(']')
+
+	return nil
+}
+
+// writeTOMLInlineTable writes a map[string]any leaf value as an inline
+// table with sorted keys, omitting nil values.
+func writeTOMLInlineTable(out *strings.Builder, table map[string]any, path keypath.KeyPath) error {
+	keys := make([]string, 0, len(table))
+
+	for key, value := range table {
+		if value != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	out.WriteByte('{')
+
+	for i, key := range keys {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+
+		out.WriteString(tomlKey(key))
+		out.WriteString(" = ")
+
+		err := writeTOMLValue(out, table[key], path.Append(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	out.WriteByte('}')
+
+	return nil
+}
+
+// tomlFloat formats a float so that it reads back as a float.
+func tomlFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "nan"
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+
+	text := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+
+	return text
+}
+
+// tomlDottedKey joins keys into a dotted TOML key.
+func tomlDottedKey(keys []string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		quoted = append(quoted, tomlKey(key))
+	}
+
+	return strings.Join(quoted, ".")
+}
+
+// tomlKey returns key as a bare key if possible, as a quoted key otherwise.
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+
+	for _, char := range key {
+		bare := char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' ||
+			char == '_' || char == '-'
+		if !bare {
+			return tomlString(key)
+		}
+	}
+
+	return key
+}
+
+// tomlString returns s as a TOML basic string.
+func tomlString(s string) string {
+	var builder strings.Builder
+
+	builder.WriteByte('"')
+
+	for _, char := range s {
+		switch char {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\r':
+			builder.WriteString(`\r`)
+		default:
+			if char < 0x20 || char == 0x7f {
+				fmt.Fprintf(&builder, `\u%04X`, char)
+			} else {
+				builder.WriteRune(char)
+			}
+		}
+	}
+
+	builder.WriteByte('"')
+
+	return builder.String()
+}
@@ -0,0 +1,141 @@
+package config_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+)
+
+func TestMarshalTOML(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildFromYAML(t, `title: demo
+server:
+  tls:
+    enabled: true
+  host: "db \"local\""
+  ports:
+    - 3301
+    - 3302
+  ratio: 2.0
+  nothing: null
+replicasets:
+  - name: r1
+    peers: [a, b]
+    weights:
+      - w: 1
+      - 2
+  - name: r2
+    limits:
+      memory: 64
+empty: {}
+none: []
+"dotted.key": x
+`)
+
+	out, err := cfg.MarshalTOML()
+	require.NoError(t, err)
+	assert.Equal(t, `title = "demo"
+none = []
+"dotted.key" = "x"
+
+[server]
+host = "db \"local\""
+ports = [3301, 3302]
+ratio = 2.0
+
+[server.tls]
+enabled = true
+
+[[replicasets]]
+name = "r1"
+peers = ["a", "b"]
+weights = [{w = 1}, 2]
+
+[[replicasets]]
+name = "r2"
+
+[replicasets.limits]
+memory = 64
+`, string(out))
+}
+
+func TestMarshalTOML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"started": started,
+		"timeout": 90 * time.Second,
+		"big":     uint64(math.MaxInt64),
+		"nan":     math.Inf(-1),
+		"text":    "line\nnext\ttab\x01",
+		"generic": map[string]any{"b": []any{1, "x"}, "a": nil},
+	}))
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	out, err := cfg.MarshalTOML()
+	require.NoError(t, err)
+
+	root, err := collectors.NewTOMLFormat().From(strings.NewReader(string(out))).Parse()
+	require.NoError(t, err, string(out))
+
+	assert.Equal(t, started, root.GetValue(config.NewKeyPath("started")))
+	assert.Equal(t, "1m30s", root.GetValue(config.NewKeyPath("timeout")))
+	assert.Equal(t, int64(math.MaxInt64), root.GetValue(config.NewKeyPath("big")))
+	assert.Equal(t, math.Inf(-1), root.GetValue(config.NewKeyPath("nan")))
+	assert.Equal(t, "line\nnext\ttab\x01", root.GetValue(config.NewKeyPath("text")))
+	assert.Equal(t, "x", root.GetValue(config.NewKeyPath("generic/b/1")))
+
+	var decoded time.Time
+
+	_, err = cfg.Get(config.NewKeyPath("started"), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, started, decoded)
+}
+
+func TestMarshalTOML_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildSensitive(t, sensitiveYAML, "credentials/users/*/password")
+
+	out, err := cfg.MarshalTOML()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "hunter2")
+	assert.Contains(t, string(out), `password = "******"`)
+}
+
+func TestMarshalTOML_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for name, data := range map[string]map[string]any{
+		"nil element": {"list": []any{1, nil}},
+		"overflow":    {"big": uint64(math.MaxUint64)},
+	} {
+		builder := config.NewBuilder()
+		builder = builder.AddCollector(collectors.NewMap(data))
+
+		cfg, errs := builder.Build(t.Context())
+		require.Empty(t, errs, name)
+
+		_, err := cfg.MarshalTOML()
+		require.ErrorIs(t, err, config.ErrTOMLUnsupported, name)
+	}
+
+	var cfg config.Config
+
+	out, err := cfg.MarshalTOML()
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
@@ -54,7 +54,12 @@ func MergeCollectorWithMerger(ctx context.Context, root *tree.Node, col Collecto
 			continue
 		}
 
-		err = merger.MergeValue(mergeCtx, root, meta.Key, raw)
+		if def, ok := merger.(*DefaultMerger); ok {
+			err = def.mergeSourceValue(mergeCtx, root, meta, raw)
+		} else {
+			err = merger.MergeValue(mergeCtx, root, meta.Key, raw)
+		}
+
 		if err != nil {
 			errs = append(errs, fmt.Errorf("merge value at %s: %w", meta.Key.String(), err))
 			continue
@@ -65,6 +70,9 @@ func MergeCollectorWithMerger(ctx context.Context, root *tree.Node, col Collecto
 		// the destination tree node so that marshalers can reproduce
 		// scalar style and comments.
 		copyAnnotation(root, meta.Key, val)
+		// Likewise forward the source position, so that diagnostics and
+		// Config.Explain can point at the line that defined the value.
+		copyRange(root, meta.Key, val)
 	}
 
 	err := mergeCtx.ApplyOrdering(root)
@@ -152,9 +160,20 @@ func mergeNodeValue(node *tree.Node, value any, col Collector) {
 		node.Value = value
 	}
 
-	// Update node metadata.
+	// Update node metadata. The position is forwarded separately by
+	// copyRange; reset it so a stale position of an overridden value
+	// does not survive.
 	node.Source = col.Name()
 	node.Revision = string(col.Revision())
+	node.Stale = isStale(col)
+	node.Range = tree.NewZeroRange()
+}
+
+// isStale reports whether the collector serves cached data.
+func isStale(col Collector) bool {
+	staleCol, ok := col.(StaleCollector)
+
+	return ok && staleCol.Stale()
 }
 
 // copyAnnotation forwards the format-specific annotation from a source Value
@@ -178,6 +197,42 @@ func copyAnnotation(root *tree.Node, path keypath.KeyPath, src Value) {
 	dest.SetAnnotation(anno)
 }
 
+// copyRange forwards the source position from a source Value onto the
+// destination tree node at path, when the source exposes a non-zero one.
+func copyRange(root *tree.Node, path keypath.KeyPath, src Value) {
+	carrier, ok := src.(interface{ Range() tree.Range })
+	if !ok {
+		return
+	}
+
+	rng := carrier.Range()
+	if rng == tree.NewZeroRange() {
+		return
+	}
+
+	dest := root.Get(path)
+	if dest == nil {
+		return
+	}
+
+	dest.Range = rng
+}
+
+// copySource sets the source name of the destination tree node at path,
+// when the source Value carries one.
+func copySource(root *tree.Node, path keypath.KeyPath, name string) {
+	if name == "" {
+		return
+	}
+
+	dest := root.Get(path)
+	if dest == nil {
+		return
+	}
+
+	dest.Source = name
+}
+
 // mergeTreeInto folds src into dst at the tree level.
 // Map-into-map is recursive; any other src child (leaf or array) replaces
 // the dst child wholesale (carrying Source, Revision, Range, annotation,
@@ -3,6 +3,8 @@ package config_test
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -501,3 +503,51 @@ func TestMergeCollector_MapToArrayConversion(t *testing.T) {
 	assert.Empty(t, thingsNode.ChildrenKeys(),
 		"all prior map children must be cleared")
 }
+
+// keepExistingMerger merges only the values at paths that are not set yet.
+type keepExistingMerger struct{}
+
+func (m *keepExistingMerger) CreateContext(col config.Collector) config.MergerContext {
+	return config.Default.CreateContext(col)
+}
+
+func (m *keepExistingMerger) MergeValue(
+	ctx config.MergerContext,
+	root *tree.Node,
+	path config.KeyPath,
+	value any,
+) error {
+	if root.Get(path) != nil {
+		return nil
+	}
+
+	return config.Default.MergeValue(ctx, root, path, value)
+}
+
+func TestMergeCollectorWithMerger_KeepsSourceOfSkippedValue(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte("host: included\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("include: base.yaml\nport: 3301\n"), 0o600))
+
+	root := tree.New()
+
+	first := collectors.NewMock().
+		WithEntry(config.NewKeyPath("host"), "localhost").
+		WithName("first")
+	require.NoError(t, config.MergeCollector(t.Context(), root, first))
+
+	col, err := collectors.NewSource(t.Context(), collectors.NewFile(filepath.Join(dir, "config.yaml")),
+		collectors.NewYamlFormat(collectors.WithYamlIncludes("")))
+	require.NoError(t, err)
+
+	err = config.MergeCollectorWithMerger(t.Context(), root, col, &keepExistingMerger{})
+	require.NoError(t, err)
+
+	host := root.Get(config.NewKeyPath("host"))
+	assert.Equal(t, "localhost", host.Value)
+	assert.Equal(t, "first", host.Source)
+
+	assert.Equal(t, col.Name(), root.Get(config.NewKeyPath("port")).Source)
+}
@@ -4,12 +4,12 @@
 // # Key Types
 //
 //   - [Info] — full metadata for a value: Key ([keypath.KeyPath]),
-//     Source ([SourceInfo]), and Revision ([RevisionType]).
+//     Source ([SourceInfo]), Revision ([RevisionType]), and Stale.
 //   - [SourceInfo] — identifies where a value came from: Name (string)
 //     and Type ([SourceType]).
 //   - [SourceType] — enum for source classification: [UnknownSource],
 //     [EnvDefaultSource], [StorageSource], [FileSource], [EnvSource],
-//     [ModifiedSource].
+//     [ModifiedSource], [FlagSource], [FlagDefaultSource], [HTTPSource].
 //   - [RevisionType] — a string identifier for the configuration revision
 //     (e.g., commit hash, timestamp). Empty when not applicable.
 package meta
@@ -13,4 +13,7 @@ type Info struct {
 	Source SourceInfo
 	// Revision number, if applicable.
 	Revision RevisionType
+	// Stale reports that the value was served from a cache because its
+	// source could not be read.
+	Stale bool
 }
@@ -18,6 +18,9 @@ func TestSourceType_ConstantsExist(t *testing.T) {
 	assert.Equal(t, meta.FileSource, meta.SourceType(3))
 	assert.Equal(t, meta.EnvSource, meta.SourceType(4))
 	assert.Equal(t, meta.ModifiedSource, meta.SourceType(5))
+	assert.Equal(t, meta.FlagSource, meta.SourceType(6))
+	assert.Equal(t, meta.FlagDefaultSource, meta.SourceType(7))
+	assert.Equal(t, meta.HTTPSource, meta.SourceType(8))
 }
 
 func TestSourceInfo_ZeroValue(t *testing.T) {
@@ -16,6 +16,12 @@ const (
 	EnvSource
 	// ModifiedSource indicates dynamically modified data (e.g., at runtime) for MutableConfig.
 	ModifiedSource
+	// FlagSource indicates command-line flags set explicitly.
+	FlagSource
+	// FlagDefaultSource indicates default values of command-line flags.
+	FlagDefaultSource
+	// HTTPSource indicates a document fetched over HTTP(S).
+	HTTPSource
 )
 
 // ModifiedSourceName is the canonical Source name set on tree nodes mutated at
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReloadDebounce is the quiet period a [Reloader] waits after the last
+// change notification before it rebuilds the configuration.
+const DefaultReloadDebounce = 100 * time.Millisecond
+
+// ReloadEvent describes the outcome of a single reload attempt.
+type ReloadEvent struct {
+	// Config is the snapshot that is current after the attempt. When the
+	// reload was rejected it is the previous snapshot, which stays published.
+	Config Config
+	// Errors holds the build and validation errors of a rejected reload.
+	// It is empty when the new snapshot was published.
+	Errors []error
+}
+
+// Accepted reports whether the reload published a new snapshot.
+func (e ReloadEvent) Accepted() bool {
+	return len(e.Errors) == 0
+}
+
+// Reloader keeps a [Config] up to date with its collectors. It subscribes to
+// every top-level collector implementing [Watcher], debounces bursts of change
+// notifications, rebuilds the configuration with the [Builder] it was created
+// from and atomically publishes the result — but only when the build (including
+// validation) succeeded. A rejected reload leaves the previous snapshot in place.
+//
+// Collectors are re-read on every rebuild, so they must return fresh data from
+// Read (or MultiCollector.Collectors) each time they are called.
+//
+// A Reloader is safe for concurrent use.
+type Reloader struct {
+	// builder assembles every snapshot.
+	builder Builder
+	// debounce is the quiet period after the last change notification.
+	debounce time.Duration
+
+	// current holds the published snapshot; nil until the first successful build.
+	current atomic.Pointer[Config]
+	// started is set while Start runs and stays set once it has succeeded.
+	started atomic.Bool
+
+	// buildMu serializes rebuilds so snapshots are published in build order.
+	buildMu sync.Mutex
+
+	// subMu guards subscribers and stopped.
+	subMu       sync.Mutex
+	subscribers map[chan ReloadEvent]struct{}
+	stopped     bool
+}
+
+// NewReloader creates a Reloader that assembles snapshots with the given
+// Builder. Call [Reloader.Start] to build the initial snapshot and begin
+// watching.
+func NewReloader(builder Builder) *Reloader {
+	return &Reloader{
+		builder:     builder,
+		debounce:    DefaultReloadDebounce,
+		current:     atomic.Pointer[Config]{},
+		started:     atomic.Bool{},
+		buildMu:     sync.Mutex{},
+		subMu:       sync.Mutex{},
+		subscribers: make(map[chan ReloadEvent]struct{}),
+		stopped:     false,
+	}
+}
+
+// WithDebounce sets the quiet period the Reloader waits after the last change
+// notification before rebuilding (default [DefaultReloadDebounce]). A value
+// <= 0 rebuilds on every notification. Must be called before Start.
+func (r *Reloader) WithDebounce(d time.Duration) *Reloader {
+	r.debounce = d
+	return r
+}
+
+// Start subscribes to every collector that implements [Watcher] and builds the
+// initial snapshot. Watching continues in the background until ctx is
+// cancelled, after which all subscription channels are closed.
+//
+// If the initial build fails, its errors are returned and nothing is watched.
+// A failing Watch call is reported as a *[CollectorError]. After a failed
+// Start the Reloader may be started again. Calling Start once it has started
+// returns [ErrReloaderStarted].
+func (r *Reloader) Start(ctx context.Context) []error {
+	if !r.started.CompareAndSwap(false, true) {
+		return []error{ErrReloaderStarted}
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	// Subscribe before the initial build so that no change made while it
+	// runs goes unnoticed.
+	var channels []<-chan WatchEvent
+
+	for _, col := range r.builder.collectors {
+		watcher, ok := col.(Watcher)
+		if !ok {
+			continue
+		}
+
+		eventCh, err := watcher.Watch(watchCtx)
+		if err != nil {
+			cancel()
+			r.started.Store(false)
+
+			return []error{NewCollectorError(col.Name(), err)}
+		}
+
+		channels = append(channels, eventCh)
+	}
+
+	r.buildMu.Lock()
+
+	cfg, errs := r.builder.Build(ctx)
+	if len(errs) > 0 {
+		r.buildMu.Unlock()
+		cancel()
+		r.started.Store(false)
+
+		return errs
+	}
+
+	r.current.Store(&cfg)
+	r.buildMu.Unlock()
+
+	go func() {
+		defer cancel()
+
+		r.run(watchCtx, fanInWatchEvents(watchCtx, channels))
+	}()
+
+	return nil
+}
+
+// Config returns the currently published snapshot. It returns an empty Config
+// before the first successful build.
+func (r *Reloader) Config() Config {
+	cfg := r.current.Load()
+	if cfg == nil {
+		return newConfig(nil, nil, nil)
+	}
+
+	return *cfg
+}
+
+// Subscribe returns a channel that receives the outcome of every subsequent
+// reload attempt, accepted or rejected. The channel holds only the latest
+// undelivered event: a slow subscriber skips intermediate events but always
+// observes the most recent one. It is closed when ctx is cancelled or when
+// the context passed to Start is cancelled.
+func (r *Reloader) Subscribe(ctx context.Context) <-chan ReloadEvent {
+	eventCh := make(chan ReloadEvent, 1)
+
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	if r.stopped {
+		close(eventCh)
+		return eventCh
+	}
+
+	r.subscribers[eventCh] = struct{}{}
+
+	context.AfterFunc(ctx, func() {
+		r.subMu.Lock()
+		defer r.subMu.Unlock()
+
+		if _, ok := r.subscribers[eventCh]; ok {
+			delete(r.subscribers, eventCh)
+			close(eventCh)
+		}
+	})
+
+	return eventCh
+}
+
+// Reload rebuilds the configuration immediately, publishes it if the build
+// succeeded and notifies subscribers. It is what the Reloader calls after a
+// debounced change notification, and can be used to trigger a reload from
+// elsewhere (e.g., on SIGHUP).
+func (r *Reloader) Reload(ctx context.Context) ReloadEvent {
+	r.buildMu.Lock()
+	defer r.buildMu.Unlock()
+
+	var event ReloadEvent
+
+	cfg, errs := r.builder.Build(ctx)
+	if len(errs) > 0 {
+		event = ReloadEvent{Config: r.Config(), Errors: errs}
+	} else {
+		r.current.Store(&cfg)
+		event = ReloadEvent{Config: cfg, Errors: nil}
+	}
+
+	r.publish(event)
+
+	return event
+}
+
+// run debounces change notifications and reloads until ctx is cancelled.
+func (r *Reloader) run(ctx context.Context, events <-chan struct{}) {
+	defer r.stop()
+
+	var (
+		timer  *time.Timer
+		timerC <-chan time.Time
+	)
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				// Every watcher closed its channel; nothing more to wait for
+				// except a pending debounced reload.
+				events = nil
+				continue
+			}
+
+			if r.debounce <= 0 {
+				r.Reload(ctx)
+				continue
+			}
+
+			if timer == nil {
+				timer = time.NewTimer(r.debounce)
+			} else {
+				timer.Reset(r.debounce)
+			}
+
+			timerC = timer.C
+		case <-timerC:
+			timerC = nil
+
+			r.Reload(ctx)
+		}
+	}
+}
+
+// publish delivers event to every subscriber, replacing an undelivered
+// older event if the subscriber has not consumed it yet.
+func (r *Reloader) publish(event ReloadEvent) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	for eventCh := range r.subscribers {
+		select {
+		case eventCh <- event:
+			continue
+		default:
+		}
+
+		select {
+		case <-eventCh:
+		default:
+		}
+
+		select {
+		case eventCh <- event:
+		default:
+		}
+	}
+}
+
+// stop closes all subscription channels; later subscriptions are closed
+// immediately.
+func (r *Reloader) stop() {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	r.stopped = true
+
+	for eventCh := range r.subscribers {
+		delete(r.subscribers, eventCh)
+		close(eventCh)
+	}
+}
+
+// fanInWatchEvents merges the watch channels into a single notification
+// channel, which is closed once every source channel is closed.
+func fanInWatchEvents(ctx context.Context, channels []<-chan WatchEvent) <-chan struct{} {
+	out := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	for _, eventCh := range channels {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case _, ok := <-eventCh:
+					if !ok {
+						return
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case out <- struct{}{}:
+					}
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
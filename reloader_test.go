@@ -0,0 +1,507 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/internal/testutil"
+	"github.com/tarantool/go-config/tree"
+	"github.com/tarantool/go-config/validator"
+	"github.com/tarantool/go-storage/watch"
+)
+
+const reloadWaitTimeout = 5 * time.Second
+
+var errWatchFailed = errors.New("watch failed")
+
+// watchedMap is a collector over mutable map data that reports changes
+// through Watch and counts how many times it was read.
+type watchedMap struct {
+	mu       sync.Mutex
+	data     map[string]any
+	reads    atomic.Int32
+	events   chan config.WatchEvent
+	watchErr error
+
+	// readsAtWatch is the number of reads made before the last Watch call.
+	readsAtWatch atomic.Int32
+	// onRead, if set, is called on every read after the data is taken.
+	onRead func()
+}
+
+func newWatchedMap(data map[string]any) *watchedMap {
+	return &watchedMap{ //nolint:exhaustruct
+		data:   data,
+		events: make(chan config.WatchEvent, 16),
+	}
+}
+
+func (w *watchedMap) set(data map[string]any) {
+	w.mu.Lock()
+	w.data = data
+	w.mu.Unlock()
+
+	w.events <- config.WatchEvent{Prefix: "map"}
+}
+
+func (w *watchedMap) Read(ctx context.Context) <-chan config.Value {
+	w.reads.Add(1)
+
+	w.mu.Lock()
+	data := w.data
+	w.mu.Unlock()
+
+	if w.onRead != nil {
+		w.onRead()
+	}
+
+	return collectors.NewMap(data).WithName(w.Name()).Read(ctx)
+}
+
+func (w *watchedMap) Watch(ctx context.Context) (<-chan config.WatchEvent, error) {
+	w.readsAtWatch.Store(w.reads.Load())
+
+	if w.watchErr != nil {
+		return nil, w.watchErr
+	}
+
+	out := make(chan config.WatchEvent)
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-w.events:
+				select {
+				case <-ctx.Done():
+					return
+				case out <- event:
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (w *watchedMap) Name() string                  { return "watched" }
+func (w *watchedMap) Source() config.SourceType     { return config.UnknownSource }
+func (w *watchedMap) Revision() config.RevisionType { return "" }
+func (w *watchedMap) KeepOrder() bool               { return false }
+
+// portValidator rejects configurations whose "port" is negative.
+type portValidator struct{}
+
+func (portValidator) Validate(root *tree.Node) []validator.ValidationError {
+	port, ok := root.GetValue(config.NewKeyPath("port")).(int)
+	if !ok || port >= 0 {
+		return nil
+	}
+
+	return []validator.ValidationError{{
+		Path:    config.NewKeyPath("port"),
+		Range:   validator.NewEmptyRange(),
+		Code:    "minimum",
+		Message: "port must be >= 0",
+	}}
+}
+
+func (portValidator) SchemaType() string { return "test" }
+
+func receiveReload(t *testing.T, eventCh <-chan config.ReloadEvent) config.ReloadEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-eventCh:
+		require.True(t, ok, "subscription closed unexpectedly")
+		return event
+	case <-time.After(reloadWaitTimeout):
+		require.FailNow(t, "no reload event received")
+	}
+
+	return config.ReloadEvent{} //nolint:exhaustruct
+}
+
+func getPort(t *testing.T, cfg config.Config) int {
+	t.Helper()
+
+	var port int
+
+	_, err := cfg.Get(config.NewKeyPath("port"), &port)
+	require.NoError(t, err)
+
+	return port
+}
+
+func TestReloader_Start_InitialSnapshot(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(newWatchedMap(map[string]any{"port": 3301}))
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	assert.Equal(t, 3301, getPort(t, reloader.Config()))
+}
+
+func TestReloader_Config_BeforeStart(t *testing.T) {
+	t.Parallel()
+
+	reloader := config.NewReloader(config.NewBuilder())
+
+	cfg := reloader.Config()
+	_, ok := cfg.Lookup(config.NewKeyPath("port"))
+	assert.False(t, ok)
+}
+
+func TestReloader_Start_Twice(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(newWatchedMap(map[string]any{"port": 3301}))
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	errs := reloader.Start(t.Context())
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], config.ErrReloaderStarted)
+}
+
+func TestReloader_Start_InitialBuildFails(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": -1})
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+	builder = builder.WithValidator(portValidator{})
+
+	reloader := config.NewReloader(builder)
+
+	errs := reloader.Start(t.Context())
+	require.Len(t, errs, 1)
+
+	var validationErr *validator.ValidationError
+	require.ErrorAs(t, errs[0], &validationErr)
+
+	// A failed Start can be retried.
+	col.set(map[string]any{"port": 3301})
+
+	require.Empty(t, reloader.Start(t.Context()))
+	assert.Equal(t, 3301, getPort(t, reloader.Config()))
+}
+
+func TestReloader_Start_WatchFails(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 3301})
+	col.watchErr = errWatchFailed
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+
+	reloader := config.NewReloader(builder)
+
+	errs := reloader.Start(t.Context())
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errWatchFailed)
+
+	var colErr *config.CollectorError
+	require.ErrorAs(t, errs[0], &colErr)
+	assert.Equal(t, "watched", colErr.CollectorName)
+
+	// Nothing is published by a failed Start, which can be retried.
+	cfg := reloader.Config()
+	_, ok := cfg.Lookup(config.NewKeyPath("port"))
+	assert.False(t, ok)
+
+	col.watchErr = nil
+
+	require.Empty(t, reloader.Start(t.Context()))
+	assert.Equal(t, 3301, getPort(t, reloader.Config()))
+}
+
+func TestReloader_Start_WatchesBeforeBuild(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 3301})
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	assert.Equal(t, int32(0), col.readsAtWatch.Load())
+	assert.Equal(t, int32(1), col.reads.Load())
+}
+
+func TestReloader_Start_ConcurrentReload(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 3301})
+
+	var held atomic.Bool
+
+	reading, release := make(chan struct{}), make(chan struct{})
+
+	// The initial build is held after it has read port 3301.
+	col.onRead = func() {
+		if held.CompareAndSwap(false, true) {
+			close(reading)
+			<-release
+		}
+	}
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+
+	reloader := config.NewReloader(builder)
+
+	started := make(chan []error)
+
+	go func() { started <- reloader.Start(t.Context()) }()
+
+	<-reading
+
+	col.mu.Lock()
+	col.data = map[string]any{"port": 3302}
+	col.mu.Unlock()
+
+	reloaded := make(chan config.ReloadEvent)
+
+	go func() { reloaded <- reloader.Reload(t.Context()) }()
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	require.Empty(t, <-started)
+
+	event := <-reloaded
+	require.True(t, event.Accepted())
+
+	// The initial snapshot does not replace the newer one of Reload.
+	assert.Equal(t, 3302, getPort(t, reloader.Config()))
+}
+
+func TestReloader_PublishesOnChange(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 3301})
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"host": "localhost"}))
+	builder = builder.AddCollector(col)
+
+	reloader := config.NewReloader(builder).WithDebounce(time.Millisecond)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	col.set(map[string]any{"port": 3302})
+
+	event := receiveReload(t, eventCh)
+	require.True(t, event.Accepted())
+	assert.Equal(t, 3302, getPort(t, event.Config))
+	assert.Equal(t, 3302, getPort(t, reloader.Config()))
+
+	var host string
+
+	cfg := reloader.Config()
+
+	_, err := cfg.Get(config.NewKeyPath("host"), &host)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+}
+
+func TestReloader_RejectedReloadKeepsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 3301})
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+	builder = builder.WithValidator(portValidator{})
+
+	reloader := config.NewReloader(builder).WithDebounce(time.Millisecond)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	col.set(map[string]any{"port": -1})
+
+	event := receiveReload(t, eventCh)
+	require.False(t, event.Accepted())
+	require.Len(t, event.Errors, 1)
+
+	var validationErr *validator.ValidationError
+	require.ErrorAs(t, event.Errors[0], &validationErr)
+	assert.Equal(t, "minimum", validationErr.Code)
+
+	assert.Equal(t, 3301, getPort(t, event.Config))
+	assert.Equal(t, 3301, getPort(t, reloader.Config()))
+}
+
+func TestReloader_DebouncesBursts(t *testing.T) {
+	t.Parallel()
+
+	col := newWatchedMap(map[string]any{"port": 1})
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+
+	reloader := config.NewReloader(builder).WithDebounce(200 * time.Millisecond)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	for port := 2; port <= 5; port++ {
+		col.set(map[string]any{"port": port})
+	}
+
+	event := receiveReload(t, eventCh)
+	require.True(t, event.Accepted())
+	assert.Equal(t, 5, getPort(t, event.Config))
+
+	// One read for the initial build and one for the debounced reload.
+	assert.Equal(t, int32(2), col.reads.Load())
+}
+
+func TestReloader_Reload_Manual(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{"port": 3301}
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(data))
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	data["port"] = 3302
+
+	event := reloader.Reload(t.Context())
+	require.True(t, event.Accepted())
+	assert.Equal(t, 3302, getPort(t, reloader.Config()))
+}
+
+func TestReloader_Subscribe_ClosedOnStop(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(newWatchedMap(map[string]any{"port": 3301}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(ctx))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	cancel()
+	testutil.Drain(t, eventCh, testutil.WithTimeout(reloadWaitTimeout))
+
+	// Subscriptions after the reloader stopped are closed immediately.
+	testutil.Drain(t, reloader.Subscribe(t.Context()), testutil.WithTimeout(reloadWaitTimeout))
+}
+
+func TestReloader_Subscribe_ClosedOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(newWatchedMap(map[string]any{"port": 3301}))
+
+	reloader := config.NewReloader(builder)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	eventCh := reloader.Subscribe(ctx)
+
+	cancel()
+	testutil.Drain(t, eventCh, testutil.WithTimeout(reloadWaitTimeout))
+}
+
+func TestReloader_StorageCollector(t *testing.T) {
+	t.Parallel()
+
+	mock := testutil.NewMockStorage()
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("port: 3301"))
+
+	typed := testutil.NewRawTyped(mock, "/config/")
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewStorage(typed, "/config/", collectors.NewYamlFormat()))
+
+	reloader := config.NewReloader(builder).WithDebounce(time.Millisecond)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	testutil.PutIntegrity(mock, "/config/", "app", []byte("port: 3302"))
+	mock.SendWatchEvent(watch.Event{Prefix: []byte("/config/app")})
+
+	event := receiveReload(t, eventCh)
+	require.True(t, event.Accepted())
+	assert.Equal(t, 3302, getPort(t, reloader.Config()))
+}
+
+func TestReloader_SourceRefreshFails(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("port: 3301\n"), 0o600))
+
+	file := collectors.NewFile(path).WithPollInterval(10 * time.Millisecond).WithDebounce(0)
+
+	collector, err := collectors.NewSource(t.Context(), file, collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collector)
+
+	reloader := config.NewReloader(builder).WithDebounce(time.Millisecond)
+	require.Empty(t, reloader.Start(t.Context()))
+
+	eventCh := reloader.Subscribe(t.Context())
+
+	// A file that no longer parses is a rejected reload.
+	replaceTestFile(t, path, "port: [\n")
+
+	event := receiveReload(t, eventCh)
+	require.False(t, event.Accepted())
+
+	var parseErr *collectors.FormatParseError
+	require.ErrorAs(t, event.Errors[0], &parseErr)
+	assert.Equal(t, 3301, getPort(t, reloader.Config()))
+
+	replaceTestFile(t, path, "port: 3302\n")
+
+	event = receiveReload(t, eventCh)
+	require.True(t, event.Accepted())
+	assert.Equal(t, 3302, getPort(t, reloader.Config()))
+}
+
+// replaceTestFile replaces the file at path by an atomic rename.
+func replaceTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+	"github.com/tarantool/go-config/validator"
+)
+
+// MaskedValue replaces the values of sensitive keys in rendered output.
+const MaskedValue = "******"
+
+// WithSensitive marks the keys matching patterns as sensitive (passwords,
+// tokens, ...). Patterns are key paths matched with [KeyPath.Match]: "*"
+// matches a single segment, "**" any number of segments, and a pattern also
+// covers everything below the keys it matches.
+//
+//	builder = builder.WithSensitive("credentials/users/*/password", "**/token")
+//
+// The values of sensitive keys are replaced with [MaskedValue] wherever the
+// configuration is rendered: [Config.MarshalYAML] and [Config.String],
+// [Config.Diff], [Config.Explain] and the messages of validation errors.
+// Programmatic access ([Config.Get], [Config.Lookup], [Config.Decode], ...)
+// still returns the real values.
+func (b *Builder) WithSensitive(patterns ...string) Builder {
+	for _, pattern := range patterns {
+		b.sensitive = append(b.sensitive, keypath.NewKeyPath(pattern))
+	}
+
+	return *b
+}
+
+// IsSensitive reports whether the key at path was marked sensitive with
+// [Builder.WithSensitive].
+func (c *Config) IsSensitive(path KeyPath) bool {
+	return matchesAny(path, c.sensitive)
+}
+
+// IsSensitive reports whether the key at path is sensitive under the
+// read-lock. See [Config.IsSensitive].
+func (mc *MutableConfig) IsSensitive(path KeyPath) bool {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.Config.IsSensitive(path)
+}
+
+// matchesAny reports whether path matches one of patterns.
+func matchesAny(path keypath.KeyPath, patterns []keypath.KeyPath) bool {
+	for _, pattern := range patterns {
+		if path.Match(pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskSensitive returns value, located at path, with the values of sensitive
+// keys replaced by MaskedValue. Maps and slices keep their shape: only the
+// sensitive leaves inside them are masked. Nil values are not masked.
+func maskSensitive(patterns []keypath.KeyPath, path keypath.KeyPath, value any) any {
+	if len(patterns) == 0 || value == nil {
+		return value
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		masked := make(map[string]any, len(typed))
+		for key, child := range typed {
+			masked[key] = maskSensitive(patterns, path.Append(key), child)
+		}
+
+		return masked
+	case []any:
+		masked := make([]any, len(typed))
+		for i, child := range typed {
+			masked[i] = maskSensitive(patterns, path.Append(strconv.Itoa(i)), child)
+		}
+
+		return masked
+	default:
+		if matchesAny(path, patterns) {
+			return MaskedValue
+		}
+
+		return value
+	}
+}
+
+// rebaseSensitive returns the patterns that apply below prefix, relative to
+// prefix: the configuration of a sub-tree (a Slice) at prefix is masked with
+// them.
+func rebaseSensitive(patterns []keypath.KeyPath, prefix keypath.KeyPath) []keypath.KeyPath {
+	var result []keypath.KeyPath
+
+	for _, pattern := range patterns {
+		result = append(result, rebasePattern(pattern, prefix)...)
+	}
+
+	return result
+}
+
+// rebasePattern strips prefix from the front of pattern. A pattern that is
+// exhausted within prefix covers the whole sub-tree and becomes the empty
+// pattern; a "**" segment may absorb any part of prefix, so it yields one
+// candidate per split point.
+func rebasePattern(pattern, prefix keypath.KeyPath) []keypath.KeyPath {
+	if len(pattern) == 0 {
+		return []keypath.KeyPath{{}}
+	}
+
+	if len(prefix) == 0 {
+		return []keypath.KeyPath{pattern}
+	}
+
+	switch pattern[0] {
+	case "**":
+		// "**" matches zero segments here, or absorbs the next one.
+		result := rebasePattern(pattern[1:], prefix)
+
+		return append(result, rebasePattern(pattern, prefix[1:])...)
+	case "*", prefix[0]:
+		return rebasePattern(pattern[1:], prefix[1:])
+	default:
+		return nil
+	}
+}
+
+// effectiveSensitive returns the patterns for the effective configuration of
+// the entity at entityPath: the patterns rebased below the entity, and the
+// patterns themselves, since an entity's configuration has the same shape
+// as the global one.
+func effectiveSensitive(patterns []keypath.KeyPath, entityPath keypath.KeyPath) []keypath.KeyPath {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	return append(rebaseSensitive(patterns, entityPath), patterns...)
+}
+
+// maskChanges masks the sensitive values of changes.
+func maskChanges(patterns []keypath.KeyPath, changes ChangeSet) {
+	if len(patterns) == 0 {
+		return
+	}
+
+	for i := range changes {
+		changes[i].OldValue = maskSensitive(patterns, changes[i].Path, changes[i].OldValue)
+		changes[i].NewValue = maskSensitive(patterns, changes[i].Path, changes[i].NewValue)
+	}
+}
+
+// redactValidationErrors removes the values of sensitive keys from the
+// messages of validation errors, which may echo the offending value. For an
+// error at path, every sensitive value at or below path is replaced with
+// MaskedValue.
+func redactValidationErrors(patterns []keypath.KeyPath, root *tree.Node, errs []validator.ValidationError) {
+	if len(patterns) == 0 || root == nil {
+		return
+	}
+
+	for i := range errs {
+		node := root.Get(errs[i].Path)
+		if node == nil {
+			continue
+		}
+
+		var secrets []string
+
+		collectSensitiveText(patterns, node, errs[i].Path, &secrets)
+
+		// Replace longer values first so that a value containing another
+		// one is not left partially visible.
+		slices.SortFunc(secrets, func(a, b string) int {
+			return cmp.Compare(len(b), len(a))
+		})
+
+		for _, secret := range secrets {
+			errs[i].Message = strings.ReplaceAll(errs[i].Message, secret, MaskedValue)
+		}
+	}
+}
+
+// collectSensitiveText appends the textual form of every sensitive leaf value
+// at or below node, located at path.
+func collectSensitiveText(patterns []keypath.KeyPath, node *tree.Node, path keypath.KeyPath, secrets *[]string) {
+	if node.IsLeaf() {
+		masked := maskSensitive(patterns, path, node.Value)
+		collectMaskedText(node.Value, masked, secrets)
+
+		return
+	}
+
+	for _, key := range node.ChildrenKeys() {
+		collectSensitiveText(patterns, node.Child(key), path.Append(key), secrets)
+	}
+}
+
+// collectMaskedText appends the textual form of the values in value that
+// maskSensitive replaced in masked.
+func collectMaskedText(value, masked any, secrets *[]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		maskedMap, _ := masked.(map[string]any)
+		for key, child := range typed {
+			collectMaskedText(child, maskedMap[key], secrets)
+		}
+	case []any:
+		maskedSlice, _ := masked.([]any)
+		for i, child := range typed {
+			collectMaskedText(child, maskedSlice[i], secrets)
+		}
+	default:
+		if masked == MaskedValue && value != MaskedValue {
+			if text := fmt.Sprint(value); text != "" {
+				*secrets = append(*secrets, text)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config"
+	"github.com/tarantool/go-config/collectors"
+	"github.com/tarantool/go-config/validator"
+)
+
+const sensitiveYAML = `credentials:
+  users:
+    admin:
+      # The admin password.
+      password: hunter2
+      roles:
+        - super
+    guest:
+      password: ""
+app:
+  token: abc
+  name: demo
+`
+
+func buildSensitive(t *testing.T, data string, patterns ...string) config.Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	col, err := collectors.NewSource(t.Context(), collectors.NewFile(path), collectors.NewYamlFormat())
+	require.NoError(t, err)
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(col)
+	builder = builder.WithSensitive(patterns...)
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	return cfg
+}
+
+func TestBuilder_WithSensitive_MarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	cfg := buildSensitive(t, sensitiveYAML, "credentials/users/*/password", "**/token")
+
+	out, err := cfg.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, `credentials:
+  users:
+    admin:
+      # The admin password.
+      password: '******'
+      roles:
+        - super
+    guest:
+      password: '******'
+app:
+  token: '******'
+  name: demo
+`, string(out))
+	assert.Equal(t, string(out), cfg.String())
+
+	// Programmatic access returns the real value.
+	assert.Equal(t, "hunter2", config.MustGet[string](&cfg, config.NewKeyPath("credentials/users/admin/password")))
+	assert.True(t, cfg.IsSensitive(config.NewKeyPath("app/token")))
+	assert.False(t, cfg.IsSensitive(config.NewKeyPath("app/name")))
+}
+
+func TestBuilder_WithSensitive_Subtree(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"secrets": map[string]any{"keys": []any{"k1", "k2"}, "nested": map[string]any{"a": 1}},
+		"public":  "ok",
+	}))
+	builder = builder.WithSensitive("secrets")
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	out, err := cfg.MarshalYAML()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "k1")
+	assert.Contains(t, string(out), "public: ok")
+
+	slice, err := cfg.Slice(config.NewKeyPath("secrets"))
+	require.NoError(t, err)
+	assert.True(t, slice.IsSensitive(config.NewKeyPath("nested/a")))
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("secrets"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{
+		"keys":   []any{config.MaskedValue, config.MaskedValue},
+		"nested": map[string]any{"a": config.MaskedValue},
+	}, explanation.Value)
+}
+
+func TestBuilder_WithSensitive_Diff(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := buildSensitive(t, sensitiveYAML, "credentials/users/*/password")
+	newCfg := buildSensitive(t, "credentials:\n  users:\n    admin:\n      password: swordfish\n", "**/password")
+
+	changes := oldCfg.Diff(&newCfg)
+
+	var found bool
+
+	for _, change := range changes {
+		if change.Path.String() != "credentials/users/admin/password" {
+			continue
+		}
+
+		found = true
+
+		assert.Equal(t, config.ChangeModified, change.Type)
+		assert.Equal(t, config.MaskedValue, change.OldValue)
+		assert.Equal(t, config.MaskedValue, change.NewValue)
+	}
+
+	assert.True(t, found)
+}
+
+func TestBuilder_WithSensitive_Explain(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"db": map[string]any{"password": "p1"}}))
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{"db": map[string]any{"password": "p2"}}))
+	builder = builder.WithSensitive("db/password")
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	explanation, ok := cfg.Explain(config.NewKeyPath("db/password"))
+	require.True(t, ok)
+	require.Len(t, explanation.Layers, 2)
+	assert.Equal(t, config.MaskedValue, explanation.Value)
+	assert.Equal(t, config.MaskedValue, explanation.Layers[0].Value)
+	assert.Equal(t, config.MaskedValue, explanation.Layers[1].Value)
+}
+
+func TestBuilder_WithSensitive_ValidationMessage(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockValidator{errors: []validator.ValidationError{{
+		Path:    config.NewKeyPath("db"),
+		Range:   validator.NewEmptyRange(),
+		Code:    "pattern",
+		Message: `"weak-secret" does not match pattern; user "admin" is fine`,
+	}}}
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"db": map[string]any{"password": "weak-secret", "user": "admin"},
+	}))
+	builder = builder.WithSensitive("db/password")
+	builder = builder.WithValidator(mock)
+
+	_, errs := builder.Build(t.Context())
+	require.Len(t, errs, 1)
+	assert.Equal(t, `db [pattern] "******" does not match pattern; user "admin" is fine`, errs[0].Error())
+}
+
+func TestBuilder_WithSensitive_Effective(t *testing.T) {
+	t.Parallel()
+
+	builder := config.NewBuilder()
+	builder = builder.AddCollector(collectors.NewMap(map[string]any{
+		"credentials": map[string]any{"password": "secret"},
+		"groups": map[string]any{
+			"g": map[string]any{
+				"replicasets": map[string]any{
+					"r": map[string]any{
+						"instances": map[string]any{"i": map[string]any{"port": 3301}},
+					},
+				},
+			},
+		},
+	}))
+	builder = builder.WithInheritance(config.Levels(config.Global, "groups", "replicasets", "instances"))
+	builder = builder.WithSensitive("credentials/password")
+
+	cfg, errs := builder.Build(t.Context())
+	require.Empty(t, errs)
+
+	effective, err := cfg.Effective(config.NewKeyPath("groups/g/replicasets/r/instances/i"))
+	require.NoError(t, err)
+	assert.True(t, effective.IsSensitive(config.NewKeyPath("credentials/password")))
+	assert.NotContains(t, effective.String(), "secret")
+	assert.Equal(t, "secret", config.MustGet[string](&effective, config.NewKeyPath("credentials/password")))
+}
@@ -75,7 +75,7 @@ func (b *Builder) WithConfigFile(path string) *Builder {
 	return b
 }
 
-// WithConfigDir sets the path to a directory of *.yaml config files.
+// WithConfigDir sets the path to a directory of *.yaml and *.yml config files.
 // Mutually exclusive with [Builder.WithConfigFile].
 func (b *Builder) WithConfigDir(path string) *Builder {
 	b.configDir = path
@@ -318,6 +318,17 @@ func tarantoolInheritanceOpts() []config.InheritanceOption {
 	}
 }
 
+// configDirFormats returns the formats of a config directory: Tarantool
+// reads YAML only, under both of its usual extensions.
+func configDirFormats() *collectors.FormatRegistry {
+	return collectors.NewFormatRegistry().Register(collectors.FormatSpec{
+		Format:     collectors.NewYamlFormat(),
+		Extensions: []string{".yaml", ".yml"},
+		MIMETypes:  nil,
+		Sniff:      nil,
+	})
+}
+
 // keyPathFromLoweredKey splits a lowercased key by "_" and returns a KeyPath
 // with empty segments filtered out.
 func keyPathFromdKey(key string) config.KeyPath {
@@ -468,7 +479,7 @@ func (b *Builder) buildInner(ctx context.Context) (config.Builder, error) {
 		sources = append(sources, source)
 	} else if b.configDir != "" {
 		sources = append(sources,
-			collectors.NewDirectory(b.configDir, ".yaml", collectors.NewYamlFormat()),
+			collectors.NewDirectory(b.configDir, "", nil).WithFormats(configDirFormats()),
 		)
 	}
 
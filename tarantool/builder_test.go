@@ -74,6 +74,33 @@ func TestBuild_ConfigDirOnly(t *testing.T) {
 	assert.Equal(t, "postgres", driver)
 }
 
+func TestBuild_ConfigDirYml(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "server.yml"), "server:\n  port: 8080\n")
+	writeFile(t, filepath.Join(dir, "database.yaml"), "database:\n  driver: postgres\n")
+	writeFile(t, filepath.Join(dir, "notes.json"), "{\"ignored\": true}\n")
+
+	cfg, err := tarantool.New().
+		WithConfigDir(dir).
+		WithoutSchema().
+		Build(context.Background())
+	require.NoError(t, err)
+
+	var port string
+
+	_, err = cfg.Get(config.NewKeyPath("server/port"), &port)
+	require.NoError(t, err)
+	assert.Equal(t, "8080", port)
+
+	_, ok := cfg.Lookup(config.NewKeyPath("database/driver"))
+	assert.True(t, ok)
+
+	_, ok = cfg.Lookup(config.NewKeyPath("ignored"))
+	assert.False(t, ok)
+}
+
 func TestBuild_MutuallyExclusive(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,115 @@
+package tree
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes that decodes from human-readable strings such
+// as "512M", "1GiB" or "64 KB", as well as from plain numbers of bytes.
+//
+// Suffixes are case-insensitive. Single-letter suffixes (K, M, G, T, P) and
+// IEC suffixes (KiB, MiB, GiB, TiB, PiB) are powers of 1024; SI suffixes
+// (KB, MB, GB, TB, PB) are powers of 1000. "B" or no suffix means bytes.
+// A fractional number is allowed with a suffix ("1.5G").
+type ByteSize uint64
+
+const byteSizeKibi = 1024
+
+// byteSizeUnits maps lower-cased suffixes to their multipliers.
+//
+//nolint:gochecknoglobals,mnd
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   byteSizeKibi,
+	"kib": byteSizeKibi,
+	"kb":  1e3,
+	"m":   math.Pow(byteSizeKibi, 2),
+	"mib": math.Pow(byteSizeKibi, 2),
+	"mb":  1e6,
+	"g":   math.Pow(byteSizeKibi, 3),
+	"gib": math.Pow(byteSizeKibi, 3),
+	"gb":  1e9,
+	"t":   math.Pow(byteSizeKibi, 4),
+	"tib": math.Pow(byteSizeKibi, 4),
+	"tb":  1e12,
+	"p":   math.Pow(byteSizeKibi, 5),
+	"pib": math.Pow(byteSizeKibi, 5),
+	"pb":  1e15,
+}
+
+// ParseByteSize parses a human-readable byte size. See [ByteSize] for the
+// accepted format.
+func ParseByteSize(str string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(str)
+
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if split < 0 {
+		split = len(trimmed)
+	}
+
+	number, unit := trimmed[:split], strings.ToLower(strings.TrimSpace(trimmed[split:]))
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("%w: %q", ErrParseByteSize, str)
+	}
+
+	if !strings.Contains(number, ".") {
+		count, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w %q: %w", ErrParseByteSize, str, err)
+		}
+
+		if multiplier > 1 && count > uint64(math.MaxUint64/multiplier) {
+			return 0, fmt.Errorf("%w: %q", ErrOverflow, str)
+		}
+
+		return ByteSize(count * uint64(multiplier)), nil
+	}
+
+	count, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrParseByteSize, str, err)
+	}
+
+	size := count * multiplier
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+
+	return ByteSize(size), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = size
+
+	return nil
+}
+
+// String returns the size with the largest IEC suffix that represents it
+// exactly, e.g. "512MiB" or "1536B".
+func (b ByteSize) String() string {
+	suffixes := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+	size := uint64(b)
+	idx := 0
+
+	for size != 0 && size%byteSizeKibi == 0 && idx < len(suffixes)-1 {
+		size /= byteSizeKibi
+		idx++
+	}
+
+	return strconv.FormatUint(size, 10) + suffixes[idx]
+}
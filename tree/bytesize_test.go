@@ -0,0 +1,61 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config/tree"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected tree.ByteSize
+	}{
+		{"0", 0},
+		{"1024", 1024},
+		{"10B", 10},
+		{"512M", 512 << 20},
+		{"512m", 512 << 20},
+		{"1GiB", 1 << 30},
+		{"64 KB", 64000},
+		{"1.5G", 3 << 29},
+		{" 2T ", 2 << 40},
+		{"1PB", 1e15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			size, err := tree.ParseByteSize(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
+func TestParseByteSize_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "M", "12X", "1.2.3G", "-1K"} {
+		_, err := tree.ParseByteSize(input)
+		require.ErrorIs(t, err, tree.ErrParseByteSize, input)
+	}
+
+	_, err := tree.ParseByteSize("20000000P")
+	require.ErrorIs(t, err, tree.ErrOverflow)
+}
+
+func TestByteSize_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0B", tree.ByteSize(0).String())
+	assert.Equal(t, "1536B", tree.ByteSize(1536).String())
+	assert.Equal(t, "512MiB", tree.ByteSize(512<<20).String())
+	assert.Equal(t, "1GiB", tree.ByteSize(1<<30).String())
+}
@@ -1,6 +1,12 @@
 package tree
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tarantool/go-config/keypath"
+)
 
 var (
 	// ErrDestinationMustBePointer is returned when destination is not a non-nil pointer.
@@ -35,4 +41,130 @@ var (
 	ErrSourceForStructMustBeMap = errors.New("source for struct must be a map")
 	// ErrSourceMapMustHaveStringKeys is returned when source map does not have string keys.
 	ErrSourceMapMustHaveStringKeys = errors.New("source map must have string keys")
+	// ErrRequiredField is returned when a struct field tagged `required` has no value.
+	ErrRequiredField = errors.New("required field is missing")
+	// ErrParseDefault is returned when a `default` struct tag cannot be parsed.
+	ErrParseDefault = errors.New("cannot parse default value")
+	// ErrUnmarshalText is returned when an encoding.TextUnmarshaler rejects a value.
+	ErrUnmarshalText = errors.New("cannot unmarshal text")
+	// ErrConvertToURL is returned when a value cannot be converted to url.URL.
+	ErrConvertToURL = errors.New("cannot convert to url.URL")
+	// ErrConvertToTime is returned when a value cannot be converted to time.Time.
+	ErrConvertToTime = errors.New("cannot convert to time.Time")
+	// ErrParseByteSize is returned when a byte size string cannot be parsed.
+	ErrParseByteSize = errors.New("cannot parse byte size")
+	// ErrUnknownKey is reported by strict decoding for a key without a matching struct field.
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrUnsetField is reported by strict decoding for a struct field without a matching key.
+	ErrUnsetField = errors.New("unset field")
 )
+
+// DecodeError is returned by Value.Get when the value, or an element nested in
+// it, cannot be decoded into the destination. Path is the full key path of the
+// element that failed: the path of the value itself followed by the keys of
+// the struct fields, map entries and slice indices leading to the failure.
+type DecodeError struct {
+	// Path is the key path of the element that failed to decode.
+	Path keypath.KeyPath
+	// Err is the underlying conversion error.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode %s: %v", e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// StrictError is returned by strict decoding (see [WithStrict]) when the
+// configuration and the destination struct do not match exactly. The
+// destination is still filled. It matches [ErrUnknownKey] and
+// [ErrUnsetField] with errors.Is, depending on which issues were found.
+type StrictError struct {
+	// UnknownKeys are the full key paths of configuration keys that match no
+	// struct field, e.g. "app/iproto/listne".
+	UnknownKeys []keypath.KeyPath
+	// UnsetFields are the full key paths of struct fields that match no
+	// configuration key.
+	UnsetFields []keypath.KeyPath
+}
+
+func (e *StrictError) Error() string {
+	var parts []string
+
+	if len(e.UnknownKeys) > 0 {
+		parts = append(parts, "unknown keys: "+joinKeyPaths(e.UnknownKeys))
+	}
+
+	if len(e.UnsetFields) > 0 {
+		parts = append(parts, "unset fields: "+joinKeyPaths(e.UnsetFields))
+	}
+
+	return "strict decode: " + strings.Join(parts, "; ")
+}
+
+func (e *StrictError) Unwrap() []error {
+	var errs []error
+
+	if len(e.UnknownKeys) > 0 {
+		errs = append(errs, ErrUnknownKey)
+	}
+
+	if len(e.UnsetFields) > 0 {
+		errs = append(errs, ErrUnsetField)
+	}
+
+	return errs
+}
+
+func joinKeyPaths(paths []keypath.KeyPath) string {
+	strs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		strs = append(strs, path.String())
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// segmentError wraps the decode error of an element nested in a composite
+// value together with the key of that element, so that the full path of the
+// failure can be reconstructed once the error unwinds.
+type segmentError struct {
+	segment string
+	context string
+	err     error
+}
+
+func newSegmentError(segment, context string, err error) *segmentError {
+	return &segmentError{
+		segment: segment,
+		context: context,
+		err:     err,
+	}
+}
+
+func (e *segmentError) Error() string {
+	return e.context + ": " + e.err.Error()
+}
+
+func (e *segmentError) Unwrap() error {
+	return e.err
+}
+
+// newDecodeError builds a DecodeError for a failure while decoding the value
+// at path, appending the keys recorded by segmentError wrappers in err.
+func newDecodeError(path keypath.KeyPath, err error) *DecodeError {
+	fullPath := append(keypath.KeyPath{}, path...)
+
+	var segErr *segmentError
+	for cur := err; errors.As(cur, &segErr); cur = segErr.err {
+		fullPath = append(fullPath, segErr.segment)
+	}
+
+	return &DecodeError{
+		Path: fullPath,
+		Err:  err,
+	}
+}
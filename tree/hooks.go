@@ -0,0 +1,312 @@
+package tree
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tarantool/go-config/keypath"
+)
+
+// DecodeHook converts a raw configuration value into a value of one specific
+// Go type. Hooks are keyed by that type: whenever the decoder needs to fill a
+// destination of the type, it calls the hook instead of its built-in
+// conversion. Create hooks with [NewDecodeHook].
+type DecodeHook struct {
+	typ reflect.Type
+	fn  func(src any) (any, error)
+}
+
+// NewDecodeHook creates a hook that decodes values of type T. The hook
+// receives the raw value (a primitive, []any or map[string]any) and returns
+// the decoded T.
+//
+//	hook := tree.NewDecodeHook(func(src any) (netip.AddrPort, error) {
+//	    return netip.ParseAddrPort(fmt.Sprint(src))
+//	})
+func NewDecodeHook[T any](fn func(src any) (T, error)) DecodeHook {
+	return DecodeHook{
+		typ: reflect.TypeFor[T](),
+		fn: func(src any) (any, error) {
+			return fn(src)
+		},
+	}
+}
+
+// Type returns the destination type the hook decodes.
+func (h DecodeHook) Type() reflect.Type {
+	return h.typ
+}
+
+// globalHooks holds the hooks registered with RegisterDecodeHook.
+//
+//nolint:gochecknoglobals
+var globalHooks = struct {
+	sync.RWMutex
+
+	hooks map[reflect.Type]DecodeHook
+}{
+	RWMutex: sync.RWMutex{},
+	hooks:   make(map[reflect.Type]DecodeHook),
+}
+
+// RegisterDecodeHook registers hooks for every decode call in the process. A
+// hook replaces a previously registered hook for the same type. Hooks passed
+// to a single call via [WithDecodeHooks] take precedence over global ones.
+func RegisterDecodeHook(hooks ...DecodeHook) {
+	globalHooks.Lock()
+	defer globalHooks.Unlock()
+
+	for _, hook := range hooks {
+		globalHooks.hooks[hook.typ] = hook
+	}
+}
+
+// UnregisterDecodeHook removes the global hook for typ, if any.
+func UnregisterDecodeHook(typ reflect.Type) {
+	globalHooks.Lock()
+	defer globalHooks.Unlock()
+
+	delete(globalHooks.hooks, typ)
+}
+
+// builtinHooks decode standard library types that neither convert from
+// strings by kind nor implement encoding.TextUnmarshaler.
+//
+//nolint:gochecknoglobals
+var builtinHooks = map[reflect.Type]DecodeHook{
+	reflect.TypeFor[url.URL]():   NewDecodeHook(decodeURL),
+	reflect.TypeFor[time.Time](): NewDecodeHook(decodeTime),
+}
+
+// DecodeOption configures a single decode call.
+type DecodeOption func(*decoder)
+
+// WithDecodeHooks adds hooks used by a single decode call. They take
+// precedence over hooks registered with [RegisterDecodeHook].
+func WithDecodeHooks(hooks ...DecodeHook) DecodeOption {
+	return func(d *decoder) {
+		if d.hooks == nil {
+			d.hooks = make(map[reflect.Type]DecodeHook, len(hooks))
+		}
+
+		for _, hook := range hooks {
+			d.hooks[hook.typ] = hook
+		}
+	}
+}
+
+// WithStrict enables strict decoding: every source key that matches no
+// struct field and every struct field that matches no key is reported in a
+// *[StrictError]. A missing field is not reported when a `default` tag fills
+// it or it is tagged `omitempty`; a missing nested struct reports its own
+// fields instead.
+func WithStrict() DecodeOption {
+	return func(d *decoder) {
+		d.strict = true
+	}
+}
+
+// decoder holds the state of a single decode call.
+type decoder struct {
+	// hooks are the per-call hooks, keyed by destination type.
+	hooks map[reflect.Type]DecodeHook
+	// strict enables reporting of unknown keys and unset fields.
+	strict bool
+	// path is the key path of the value being decoded; it is tracked only
+	// in strict mode.
+	path keypath.KeyPath
+	// unknown and unset collect the issues found in strict mode.
+	unknown []keypath.KeyPath
+	unset   []keypath.KeyPath
+}
+
+func newDecoder(path keypath.KeyPath, opts []DecodeOption) *decoder {
+	d := &decoder{
+		hooks:   nil,
+		strict:  false,
+		path:    path,
+		unknown: nil,
+		unset:   nil,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+
+	return d
+}
+
+// decodeAt decodes src into dst, the element at segment below the current
+// path.
+func (d *decoder) decodeAt(segment string, src any, dst reflect.Value) error {
+	if !d.strict {
+		return d.decode(src, dst)
+	}
+
+	parent := d.path
+	d.path = parent.Append(segment)
+
+	err := d.decode(src, dst)
+
+	d.path = parent
+
+	return err
+}
+
+// reportUnknownKeys records the keys of srcVal that are not in known, in
+// sorted order.
+func (d *decoder) reportUnknownKeys(srcVal reflect.Value, known map[string]struct{}) {
+	var unknown []string
+
+	iter := srcVal.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	for _, key := range unknown {
+		d.unknown = append(d.unknown, d.path.Append(key))
+	}
+}
+
+// strictError returns the issues collected in strict mode, if any.
+func (d *decoder) strictError() error {
+	if len(d.unknown) == 0 && len(d.unset) == 0 {
+		return nil
+	}
+
+	return &StrictError{
+		UnknownKeys: d.unknown,
+		UnsetFields: d.unset,
+	}
+}
+
+// userHook returns the per-call or global hook for typ.
+func (d *decoder) userHook(typ reflect.Type) (DecodeHook, bool) {
+	if hook, ok := d.hooks[typ]; ok {
+		return hook, true
+	}
+
+	globalHooks.RLock()
+	defer globalHooks.RUnlock()
+
+	hook, ok := globalHooks.hooks[typ]
+
+	return hook, ok
+}
+
+// hasHook reports whether values of typ are decoded by a hook rather than
+// field by field.
+func (d *decoder) hasHook(typ reflect.Type) bool {
+	if _, ok := builtinHooks[typ]; ok {
+		return true
+	}
+
+	_, ok := d.userHook(typ)
+
+	return ok
+}
+
+// applyHook runs hook on src and stores the result into dst.
+func applyHook(hook DecodeHook, src any, dst reflect.Value) error {
+	result, err := hook.fn(src)
+	if err != nil {
+		return err
+	}
+
+	resultVal := reflect.ValueOf(result)
+	if !resultVal.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	dst.Set(resultVal)
+
+	return nil
+}
+
+// decodeText decodes a string (or []byte) src with the destination's
+// encoding.TextUnmarshaler implementation. It reports false when dst does not
+// implement the interface or src is not textual.
+func decodeText(src any, dst reflect.Value) (bool, error) {
+	var text []byte
+
+	switch typedSrc := src.(type) {
+	case string:
+		text = []byte(typedSrc)
+	case []byte:
+		text = typedSrc
+	default:
+		return false, nil
+	}
+
+	if !dst.CanAddr() {
+		return false, nil
+	}
+
+	unmarshaler, ok := dst.Addr().Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return false, nil
+	}
+
+	err := unmarshaler.UnmarshalText(text)
+	if err != nil {
+		return true, fmt.Errorf("%w %q into %v: %w", ErrUnmarshalText, text, dst.Type(), err)
+	}
+
+	return true, nil
+}
+
+// decodeURL parses a URL from a string.
+func decodeURL(src any) (url.URL, error) {
+	str, ok := src.(string)
+	if !ok {
+		return url.URL{}, fmt.Errorf("%w: %T", ErrConvertToURL, src) //nolint:exhaustruct
+	}
+
+	parsed, err := url.Parse(str)
+	if err != nil {
+		return url.URL{}, fmt.Errorf("%w %q: %w", ErrConvertToURL, str, err) //nolint:exhaustruct
+	}
+
+	return *parsed, nil
+}
+
+// timeLayouts are the layouts accepted for time.Time values, tried in order.
+//
+//nolint:gochecknoglobals
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05.999999999", "2006-01-02"}
+
+// decodeTime parses a time from an RFC 3339 string, a "2006-01-02 15:04:05",
+// "2006-01-02T15:04:05" or "2006-01-02" string (in UTC), or Unix seconds. A
+// time.Time value (e.g. a TOML date-time) is taken as is.
+func decodeTime(src any) (time.Time, error) {
+	switch typedSrc := src.(type) {
+	case time.Time:
+		return typedSrc, nil
+	case string:
+		for _, layout := range timeLayouts {
+			parsed, err := time.Parse(layout, typedSrc)
+			if err == nil {
+				return parsed, nil
+			}
+		}
+
+		return time.Time{}, fmt.Errorf("%w %q", ErrConvertToTime, typedSrc)
+	case int, int8, int16, int32, int64:
+		return time.Unix(reflect.ValueOf(typedSrc).Int(), 0).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %T", ErrConvertToTime, src)
+	}
+}
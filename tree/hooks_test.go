@@ -0,0 +1,274 @@
+package tree_test
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-config/keypath"
+	"github.com/tarantool/go-config/tree"
+)
+
+type hookEndpoint struct {
+	Host string
+	Port string
+}
+
+func parseHookEndpoint(src any) (hookEndpoint, error) {
+	str, ok := src.(string)
+	if !ok {
+		return hookEndpoint{}, errors.New("endpoint must be a string")
+	}
+
+	host, port, ok := strings.Cut(str, ":")
+	if !ok {
+		return hookEndpoint{}, errors.New("endpoint must be host:port")
+	}
+
+	return hookEndpoint{Host: host, Port: port}, nil
+}
+
+func decodeFromMap(t *testing.T, data map[string]any, dest any, opts ...tree.DecodeOption) error {
+	t.Helper()
+
+	root := tree.New()
+	for key, value := range data {
+		root.Set(keypath.NewKeyPath(key), value)
+	}
+
+	return tree.Decode(root, nil, dest, opts...)
+}
+
+func TestDecode_BuiltinTypes(t *testing.T) {
+	t.Parallel()
+
+	type target struct {
+		IP      net.IP         `yaml:"ip"`
+		URL     url.URL        `yaml:"url"`
+		URLPtr  *url.URL       `yaml:"url_ptr"`
+		Time    time.Time      `yaml:"time"`
+		Date    time.Time      `yaml:"date"`
+		Unix    time.Time      `yaml:"unix"`
+		Local   time.Time      `yaml:"local"`
+		Typed   time.Time      `yaml:"typed"`
+		Pattern *regexp.Regexp `yaml:"pattern"`
+		Size    tree.ByteSize  `yaml:"size"`
+	}
+
+	var dest target
+
+	err := decodeFromMap(t, map[string]any{
+		"ip":      "10.0.0.1",
+		"url":     "http://localhost:8080/path",
+		"url_ptr": "https://example.com",
+		"time":    "2024-05-01T10:00:00Z",
+		"date":    "2024-05-01",
+		"unix":    1714557600,
+		"local":   "2024-05-01T10:00:00",
+		"typed":   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"pattern": "^a+$",
+		"size":    "512M",
+	}, &dest)
+	require.NoError(t, err)
+
+	assert.Equal(t, net.ParseIP("10.0.0.1"), dest.IP)
+	assert.Equal(t, "localhost:8080", dest.URL.Host)
+	require.NotNil(t, dest.URLPtr)
+	assert.Equal(t, "example.com", dest.URLPtr.Host)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), dest.Time)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), dest.Date)
+	assert.True(t, dest.Unix.Equal(dest.Time))
+	assert.Equal(t, dest.Time, dest.Local)
+	assert.Equal(t, dest.Time, dest.Typed)
+	require.NotNil(t, dest.Pattern)
+	assert.True(t, dest.Pattern.MatchString("aaa"))
+	assert.Equal(t, tree.ByteSize(512<<20), dest.Size)
+}
+
+func TestDecode_TextUnmarshalerError(t *testing.T) {
+	t.Parallel()
+
+	var dest struct {
+		Pattern *regexp.Regexp `yaml:"pattern"`
+	}
+
+	err := decodeFromMap(t, map[string]any{"pattern": "(unclosed"}, &dest)
+	require.Error(t, err)
+	require.ErrorIs(t, err, tree.ErrUnmarshalText)
+
+	var decodeErr *tree.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, keypath.NewKeyPath("pattern"), decodeErr.Path)
+}
+
+func TestDecode_PerCallHook(t *testing.T) {
+	t.Parallel()
+
+	var dest struct {
+		Endpoints []hookEndpoint `yaml:"endpoints"`
+	}
+
+	hook := tree.NewDecodeHook(parseHookEndpoint)
+	assert.Equal(t, reflect.TypeFor[hookEndpoint](), hook.Type())
+
+	err := decodeFromMap(t, map[string]any{"endpoints": []any{"a:1", "b:2"}}, &dest,
+		tree.WithDecodeHooks(hook))
+	require.NoError(t, err)
+	assert.Equal(t, []hookEndpoint{{Host: "a", Port: "1"}, {Host: "b", Port: "2"}}, dest.Endpoints)
+
+	err = decodeFromMap(t, map[string]any{"endpoints": []any{"a:1", "bad"}}, &dest,
+		tree.WithDecodeHooks(hook))
+	require.Error(t, err)
+
+	var decodeErr *tree.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, keypath.NewKeyPath("endpoints/1"), decodeErr.Path)
+}
+
+func TestDecode_HookOverridesBuiltin(t *testing.T) {
+	t.Parallel()
+
+	var dest struct {
+		Size tree.ByteSize `yaml:"size"`
+	}
+
+	hook := tree.NewDecodeHook(func(any) (tree.ByteSize, error) {
+		return 42, nil
+	})
+
+	err := decodeFromMap(t, map[string]any{"size": "1G"}, &dest, tree.WithDecodeHooks(hook))
+	require.NoError(t, err)
+	assert.Equal(t, tree.ByteSize(42), dest.Size)
+}
+
+type globalHookValue struct {
+	Source string
+}
+
+func TestDecode_GlobalHook(t *testing.T) {
+	t.Parallel()
+
+	tree.RegisterDecodeHook(tree.NewDecodeHook(func(src any) (globalHookValue, error) {
+		return globalHookValue{Source: "global"}, nil
+	}))
+	t.Cleanup(func() { tree.UnregisterDecodeHook(reflect.TypeFor[globalHookValue]()) })
+
+	var dest globalHookValue
+
+	err := decodeFromMap(t, map[string]any{"value": "x"}, &dest)
+	require.NoError(t, err)
+	assert.Equal(t, "global", dest.Source)
+
+	// A per-call hook takes precedence over the global one.
+	err = decodeFromMap(t, map[string]any{"value": "x"}, &dest,
+		tree.WithDecodeHooks(tree.NewDecodeHook(func(src any) (globalHookValue, error) {
+			return globalHookValue{Source: "call"}, nil
+		})))
+	require.NoError(t, err)
+	assert.Equal(t, "call", dest.Source)
+}
+
+func TestDecode_MissingHookedStruct(t *testing.T) {
+	t.Parallel()
+
+	var dest struct {
+		Name    string    `yaml:"name"`
+		Started time.Time `yaml:"started"`
+		URL     url.URL   `yaml:"url"`
+	}
+
+	require.NoError(t, decodeFromMap(t, map[string]any{"name": "x"}, &dest))
+	assert.True(t, dest.Started.IsZero())
+	assert.Equal(t, url.URL{}, dest.URL)
+}
+
+func TestDecode_Strict(t *testing.T) {
+	t.Parallel()
+
+	type iproto struct {
+		Listen  string `yaml:"listen"`
+		Threads int    `default:"1" yaml:"threads"`
+	}
+
+	type Base struct {
+		Name string `yaml:"name"`
+	}
+
+	type app struct {
+		Base `yaml:",inline"`
+
+		IProto  iproto            `yaml:"iproto"`
+		Labels  map[string]string `yaml:"labels,omitempty"`
+		Workers []iproto          `yaml:"workers"`
+		Owner   string            `yaml:"owner"`
+	}
+
+	var dest app
+
+	err := decodeFromMap(t, map[string]any{
+		"name":          "app",
+		"iproto/listne": "localhost:3301",
+		"workers":       []any{map[string]any{"listen": "w1", "extra": true}},
+		"unknown":       1,
+	}, &dest, tree.WithStrict())
+	require.Error(t, err)
+	require.ErrorIs(t, err, tree.ErrUnknownKey)
+	require.ErrorIs(t, err, tree.ErrUnsetField)
+
+	var strictErr *tree.StrictError
+	require.ErrorAs(t, err, &strictErr)
+	assert.Equal(t, []keypath.KeyPath{
+		keypath.NewKeyPath("iproto/listne"),
+		keypath.NewKeyPath("workers/0/extra"),
+		keypath.NewKeyPath("unknown"),
+	}, strictErr.UnknownKeys)
+	assert.Equal(t, []keypath.KeyPath{
+		keypath.NewKeyPath("iproto/listen"),
+		keypath.NewKeyPath("owner"),
+	}, strictErr.UnsetFields)
+	assert.Equal(t,
+		"strict decode: unknown keys: iproto/listne, workers/0/extra, unknown; unset fields: iproto/listen, owner",
+		err.Error())
+
+	// The destination is filled regardless.
+	assert.Equal(t, "app", dest.Name)
+	assert.Equal(t, 1, dest.IProto.Threads)
+	assert.Equal(t, "w1", dest.Workers[0].Listen)
+}
+
+func TestDecode_StrictExactMatch(t *testing.T) {
+	t.Parallel()
+
+	var dest struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+
+	root := tree.New()
+	root.Set(keypath.NewKeyPath("server/host"), "localhost")
+	root.Set(keypath.NewKeyPath("server/port"), 3301)
+
+	path := keypath.NewKeyPath("server")
+	require.NoError(t, tree.Decode(root.Get(path), path, &dest, tree.WithStrict()))
+
+	root.Set(keypath.NewKeyPath("server/hots"), "typo")
+
+	err := tree.Decode(root.Get(path), path, &dest, tree.WithStrict())
+	require.ErrorIs(t, err, tree.ErrUnknownKey)
+	assert.NotErrorIs(t, err, tree.ErrUnsetField)
+
+	var strictErr *tree.StrictError
+	require.ErrorAs(t, err, &strictErr)
+	assert.Equal(t, []keypath.KeyPath{keypath.NewKeyPath("server/hots")}, strictErr.UnknownKeys)
+
+	// Without the option extra keys are ignored.
+	require.NoError(t, tree.Decode(root.Get(path), path, &dest))
+}
@@ -21,6 +21,10 @@ type Node struct {
 	// Revision is a version identifier for the node (e.g., commit hash, timestamp).
 	Revision string
 
+	// Stale indicates that the value was served from a cache because its
+	// source could not be read.
+	Stale bool
+
 	// Range indicates the position in source file where this node was defined.
 	Range Range
 
@@ -30,6 +34,10 @@ type Node struct {
 	// comments. The tree package treats it as opaque.
 	annotation any
 
+	// unexpanded holds the value as read from the source when Value is the
+	// result of interpolation; nil otherwise.
+	unexpanded Value
+
 	// isArray indicates that this node represents a YAML sequence (array).
 	// Children are indexed by "0", "1", "2", etc.
 	isArray bool
@@ -47,9 +55,11 @@ func New() *Node {
 		Value:    nil,
 		Source:   "",
 		Revision: "",
+		Stale:    false,
 		Range:    Range{Start: Position{Line: 0, Column: 0}, End: Position{Line: 0, Column: 0}},
 
 		annotation: nil,
+		unexpanded: nil,
 		isArray:    false,
 		children:   nil,
 		orderSet:   false,
@@ -70,6 +80,20 @@ func (n *Node) SetAnnotation(a any) {
 	n.annotation = a
 }
 
+// Unexpanded returns the value as read from the source, before
+// interpolation replaced it, and reports whether Value was interpolated.
+// Marshalers use it to write back the original references (e.g.
+// "${env:PASSWORD}") rather than their expansions.
+func (n *Node) Unexpanded() (Value, bool) {
+	return n.unexpanded, n.unexpanded != nil
+}
+
+// SetUnexpanded records the value the node held before interpolation.
+// Passing nil clears it. Setting the node's value via Set clears it as well.
+func (n *Node) SetUnexpanded(v Value) {
+	n.unexpanded = v
+}
+
 // IsLeaf returns true if the node has no children.
 func (n *Node) IsLeaf() bool {
 	if n.children == nil {
@@ -152,6 +176,8 @@ func (n *Node) DeleteChild(key string) bool {
 func (n *Node) Set(path keypath.KeyPath, value Value) {
 	if len(path) == 0 {
 		n.Value = value
+		n.unexpanded = nil
+
 		return
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/tarantool/go-config/keypath"
 	"github.com/tarantool/go-config/meta"
 	"github.com/tarantool/go-config/value"
+	"go.yaml.in/yaml/v3"
 )
 
 // valueImpl is the internal implementation of the value.Value interface.
@@ -19,10 +20,11 @@ type valueImpl struct {
 	keyPath keypath.KeyPath
 	source  meta.SourceInfo
 	rev     meta.RevisionType
+	stale   bool
 }
 
 // NewValue creates a new value.Value from a tree node and its key path.
-// The source information and revision are extracted from the node.
+// The source information, revision and staleness are extracted from the node.
 func NewValue(node *Node, keyPath keypath.KeyPath) value.Value {
 	var source meta.SourceInfo
 	if node.Source != "" {
@@ -39,11 +41,24 @@ func NewValue(node *Node, keyPath keypath.KeyPath) value.Value {
 		keyPath: keyPath,
 		source:  source,
 		rev:     rev,
+		stale:   node.Stale,
 	}
 }
 
 // Get implements value.Value.Get.
 func (v *valueImpl) Get(dest any) error {
+	return Decode(v.node, v.keyPath, dest)
+}
+
+// Decode decodes the subtree rooted at node into dest, which must be a
+// non-nil pointer. keyPath is the location of node and is used to report
+// the full path of a failing element in a *[DecodeError]. Options such as
+// [WithDecodeHooks] configure this call only.
+//
+// With [WithStrict], dest is filled as usual, but keys without a matching
+// struct field and struct fields without a matching key are collected and
+// reported as a *[StrictError] once decoding completes.
+func Decode(node *Node, keyPath keypath.KeyPath, dest any, opts ...DecodeOption) error {
 	// Ensure dest is a pointer.
 	destVal := reflect.ValueOf(dest)
 	if destVal.Kind() != reflect.Pointer || destVal.IsNil() {
@@ -51,9 +66,16 @@ func (v *valueImpl) Get(dest any) error {
 	}
 
 	// Convert the node to a generic value.
-	raw := nodeToValue(v.node)
+	raw := nodeToValue(node)
 	// Decode raw into dest using reflection.
-	return decode(raw, destVal.Elem())
+	dec := newDecoder(keyPath, opts)
+
+	err := dec.decode(raw, destVal.Elem())
+	if err != nil {
+		return newDecodeError(keyPath, err)
+	}
+
+	return dec.strictError()
 }
 
 // Meta implements value.Value.Meta.
@@ -62,6 +84,7 @@ func (v *valueImpl) Meta() meta.Info {
 		Key:      v.keyPath,
 		Source:   v.source,
 		Revision: v.rev,
+		Stale:    v.stale,
 	}
 }
 
@@ -77,6 +100,17 @@ func (v *valueImpl) Annotation() any {
 	return v.node.Annotation()
 }
 
+// Range returns the source position of the underlying tree node. Like
+// Annotation, it is discovered by type assertion so that the merge pipeline
+// can carry positions from collector trees into the merged configuration.
+func (v *valueImpl) Range() Range {
+	if v.node == nil {
+		return NewZeroRange()
+	}
+
+	return v.node.Range
+}
+
 // nodeToValue converts a tree node into a generic Go value.
 //
 // Array nodes are handled before the leaf check: a populated array yields a
@@ -127,7 +161,7 @@ func nodeToValue(node *Node) any {
 }
 
 // decode decodes a generic value into a reflect.Value destination.
-func decode(src any, dst reflect.Value) error {
+func (d *decoder) decode(src any, dst reflect.Value) error {
 	// Handle nil source.
 	if src == nil {
 		// Set zero value.
@@ -135,6 +169,12 @@ func decode(src any, dst reflect.Value) error {
 		return nil
 	}
 
+	// Hooks registered for the destination type take precedence over
+	// every built-in conversion.
+	if hook, ok := d.userHook(dst.Type()); ok {
+		return applyHook(hook, src, dst)
+	}
+
 	// Convert source to reflect.Value.
 	srcVal := reflect.ValueOf(src)
 	// If types are directly assignable, assign.
@@ -143,6 +183,17 @@ func decode(src any, dst reflect.Value) error {
 		return nil
 	}
 
+	// Standard library types with dedicated parsers (url.URL, time.Time).
+	if hook, ok := builtinHooks[dst.Type()]; ok {
+		return applyHook(hook, src, dst)
+	}
+
+	// Types that parse themselves from text (net.IP, regexp.Regexp, ByteSize, ...).
+	handled, err := decodeText(src, dst)
+	if handled {
+		return err
+	}
+
 	// Check for time.Duration special case.
 	if dst.Type() == reflect.TypeFor[time.Duration]() {
 		return decodeDuration(src, dst)
@@ -161,13 +212,13 @@ func decode(src any, dst reflect.Value) error {
 	case reflect.String:
 		return decodeString(src, dst)
 	case reflect.Slice:
-		return decodeSlice(src, dst)
+		return d.decodeSlice(src, dst)
 	case reflect.Map:
-		return decodeMap(src, dst)
+		return d.decodeMap(src, dst)
 	case reflect.Struct:
-		return decodeStruct(src, dst)
+		return d.decodeStruct(src, dst)
 	case reflect.Pointer:
-		return decodePtr(src, dst)
+		return d.decodePtr(src, dst)
 	case reflect.Interface:
 		// Assign directly if src type implements the interface.
 		if srcVal.Type().Implements(dst.Type()) {
@@ -530,7 +581,7 @@ func decodeString(src any, dst reflect.Value) error {
 }
 
 // decodeSlice converts src to slice.
-func decodeSlice(src any, dst reflect.Value) error {
+func (d *decoder) decodeSlice(src any, dst reflect.Value) error {
 	srcVal := reflect.ValueOf(src)
 	if srcVal.Kind() != reflect.Slice && srcVal.Kind() != reflect.Array {
 		return fmt.Errorf("%w: %T", ErrSourceNotSliceOrArray, src)
@@ -542,9 +593,9 @@ func decodeSlice(src any, dst reflect.Value) error {
 	for i := range length {
 		elem := srcVal.Index(i).Interface()
 
-		err := decode(elem, slice.Index(i))
+		err := d.decodeAt(strconv.Itoa(i), elem, slice.Index(i))
 		if err != nil {
-			return fmt.Errorf("slice element [%d]: %w", i, err)
+			return newSegmentError(strconv.Itoa(i), fmt.Sprintf("slice element [%d]", i), err)
 		}
 	}
 
@@ -554,7 +605,7 @@ func decodeSlice(src any, dst reflect.Value) error {
 }
 
 // decodeMap converts src to map.
-func decodeMap(src any, dst reflect.Value) error {
+func (d *decoder) decodeMap(src any, dst reflect.Value) error {
 	srcVal := reflect.ValueOf(src)
 	if srcVal.Kind() != reflect.Map {
 		return fmt.Errorf("%w: %T", ErrSourceNotMap, src)
@@ -579,9 +630,9 @@ func decodeMap(src any, dst reflect.Value) error {
 		// Create a new value of the map's element type.
 		elem := reflect.New(mapType.Elem()).Elem()
 
-		err := decode(iter.Value().Interface(), elem)
+		err := d.decodeAt(key.String(), iter.Value().Interface(), elem)
 		if err != nil {
-			return fmt.Errorf("map key %q: %w", key.String(), err)
+			return newSegmentError(key.String(), fmt.Sprintf("map key %q", key.String()), err)
 		}
 
 		newMap.SetMapIndex(key, elem)
@@ -593,7 +644,7 @@ func decodeMap(src any, dst reflect.Value) error {
 }
 
 // decodeStruct converts src to struct.
-func decodeStruct(src any, dst reflect.Value) error {
+func (d *decoder) decodeStruct(src any, dst reflect.Value) error {
 	srcVal := reflect.ValueOf(src)
 	// Source must be a map[string]any.
 	if srcVal.Kind() != reflect.Map {
@@ -604,6 +655,24 @@ func decodeStruct(src any, dst reflect.Value) error {
 		return ErrSourceMapMustHaveStringKeys
 	}
 
+	known := make(map[string]struct{}, dst.NumField())
+
+	err := d.decodeFields(srcVal, dst, known)
+	if err != nil {
+		return err
+	}
+
+	if d.strict {
+		d.reportUnknownKeys(srcVal, known)
+	}
+
+	return nil
+}
+
+// decodeFields decodes the fields of the struct dst from the source map
+// srcVal, recording the keys the fields (including inlined ones) map to in
+// known.
+func (d *decoder) decodeFields(srcVal, dst reflect.Value, known map[string]struct{}) error {
 	// Iterate over struct fields.
 	dstType := dst.Type()
 	for i := range dstType.NumField() {
@@ -619,7 +688,7 @@ func decodeStruct(src any, dst reflect.Value) error {
 		// `,inline` is flattened, i.e. its own fields are looked up in the
 		// parent map at the current level rather than under a key.
 		if opts.Has("inline") && (field.Anonymous || name == "") {
-			handled, err := decodeInlineField(src, dst.Field(i), field.Name)
+			handled, err := d.decodeInlineField(srcVal, dst.Field(i), field.Name, known)
 			if err != nil {
 				return err
 			}
@@ -634,34 +703,105 @@ func decodeStruct(src any, dst reflect.Value) error {
 			name = field.Name
 		}
 
-		// Look up key in source map.
+		known[name] = struct{}{}
+
+		// Look up key in source map. With `omitempty`, an empty value
+		// (null, "", empty map or slice) counts as missing.
 		key := reflect.ValueOf(name)
 
 		val := srcVal.MapIndex(key)
-		if !val.IsValid() {
-			// Field not found; leave zero value.
+		if !val.IsValid() || (opts.Has("omitempty") && isEmptySource(val)) {
+			err := d.decodeMissingField(name, field, opts, dst.Field(i))
+			if err != nil {
+				return newSegmentError(name, fmt.Sprintf("field %q", field.Name), err)
+			}
+
 			continue
 		}
 
 		// Decode into field.
-		err := decode(val.Interface(), dst.Field(i))
+		err := d.decodeAt(name, val.Interface(), dst.Field(i))
+		if err != nil {
+			return newSegmentError(name, fmt.Sprintf("field %q", field.Name), err)
+		}
+	}
+
+	return nil
+}
+
+// decodeMissingField fills a struct field whose key is missing from the
+// source map. A `default:"..."` tag is parsed as a YAML value and decoded
+// into the field; otherwise a field with the `required` option fails with
+// ErrRequiredField. A nested struct without a default is decoded from an
+// empty map so that its own defaults and required fields apply, unless a
+// hook decodes its type (e.g. time.Time). Any other
+// field is left untouched and, in strict mode, reported as unset unless it
+// is tagged `omitempty`.
+func (d *decoder) decodeMissingField(
+	name string,
+	field reflect.StructField,
+	opts structtag.Options,
+	fieldVal reflect.Value,
+) error {
+	if def, ok := field.Tag.Lookup("default"); ok {
+		var parsed any
+
+		err := yaml.Unmarshal([]byte(def), &parsed)
 		if err != nil {
-			return fmt.Errorf("field %q: %w", field.Name, err)
+			return fmt.Errorf("%w %q: %w", ErrParseDefault, def, err)
 		}
+
+		return d.decodeAt(name, parsed, fieldVal)
+	}
+
+	if opts.Has("required") {
+		return ErrRequiredField
+	}
+
+	if fieldVal.Kind() == reflect.Struct && !d.hasHook(fieldVal.Type()) {
+		return d.decodeAt(name, map[string]any{}, fieldVal)
+	}
+
+	if d.strict && !opts.Has("omitempty") {
+		d.unset = append(d.unset, d.path.Append(name))
 	}
 
 	return nil
 }
 
+// isEmptySource reports whether a source map value is empty: null, an empty
+// string, or an empty map or slice.
+func isEmptySource(val reflect.Value) bool {
+	if val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+
+	if !val.IsValid() {
+		return true
+	}
+
+	kind := val.Kind()
+	if kind == reflect.String || kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array {
+		return val.Len() == 0
+	}
+
+	return false
+}
+
 // decodeInlineField flattens a field tagged `,inline` by decoding the parent
-// source map into it, and reports whether it consumed the field.
+// source map into it, and reports whether it consumed the field. The keys of
+// the inlined fields are recorded in known.
 //
 // Only structs and pointers-to-struct flatten. A map tagged `,inline` (the
 // go-yaml catch-all) is deliberately not supported: decoding the whole parent
 // map into it would let it swallow keys that sibling fields already own, and
 // implementing it correctly requires assigning only the leftover keys. Such a
 // field is left unhandled so the caller falls back to a normal by-name lookup.
-func decodeInlineField(src any, fieldVal reflect.Value, fieldName string) (bool, error) {
+func (d *decoder) decodeInlineField(
+	srcVal, fieldVal reflect.Value,
+	fieldName string,
+	known map[string]struct{},
+) (bool, error) {
 	elemType := fieldVal.Type()
 	if elemType.Kind() == reflect.Pointer {
 		elemType = elemType.Elem()
@@ -671,7 +811,15 @@ func decodeInlineField(src any, fieldVal reflect.Value, fieldName string) (bool,
 		return false, nil
 	}
 
-	err := decode(src, fieldVal)
+	if fieldVal.Kind() == reflect.Pointer {
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.New(elemType))
+		}
+
+		fieldVal = fieldVal.Elem()
+	}
+
+	err := d.decodeFields(srcVal, fieldVal, known)
 	if err != nil {
 		return true, fmt.Errorf("inline field %q: %w", fieldName, err)
 	}
@@ -680,12 +828,12 @@ func decodeInlineField(src any, fieldVal reflect.Value, fieldName string) (bool,
 }
 
 // decodePtr converts src to pointer.
-func decodePtr(src any, dst reflect.Value) error {
+func (d *decoder) decodePtr(src any, dst reflect.Value) error {
 	// If dst is nil, allocate a new value.
 	if dst.IsNil() {
 		dst.Set(reflect.New(dst.Type().Elem()))
 	}
 
 	// Dereference and decode.
-	return decode(src, dst.Elem())
+	return d.decode(src, dst.Elem())
 }
@@ -1900,3 +1900,185 @@ func TestValue_Get_ArrayNode_SliceInValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []int{1, 2, 3}, got)
 }
+
+func TestValue_Get_DecodeErrorPath(t *testing.T) {
+	t.Parallel()
+
+	type listen struct {
+		URI  string `yaml:"uri"`
+		Port int    `yaml:"port"`
+	}
+
+	type server struct {
+		Listen []listen           `yaml:"listen"`
+		Limits map[string]float64 `yaml:"limits"`
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		wantPath string
+	}{
+		{
+			name: "struct field in slice element",
+			data: map[string]any{
+				"listen": []any{
+					map[string]any{"uri": "a", "port": 1},
+					map[string]any{"uri": "b", "port": "not-a-port"},
+				},
+			},
+			wantPath: "server/listen/1/port",
+		},
+		{
+			name:     "map entry",
+			data:     map[string]any{"limits": map[string]any{"rps": "fast"}},
+			wantPath: "server/limits/rps",
+		},
+		{
+			name:     "value itself",
+			data:     nil,
+			wantPath: "server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := tree.New()
+			if tt.data != nil {
+				root.Set(keypath.NewKeyPath("server"), tt.data)
+			} else {
+				root.Set(keypath.NewKeyPath("server"), "scalar")
+			}
+
+			val := tree.NewValue(root.Get(keypath.NewKeyPath("server")), keypath.NewKeyPath("server"))
+
+			var dest server
+
+			err := val.Get(&dest)
+			require.Error(t, err)
+
+			var decodeErr *tree.DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			assert.Equal(t, keypath.NewKeyPath(tt.wantPath), decodeErr.Path)
+			assert.Contains(t, err.Error(), tt.wantPath)
+		})
+	}
+}
+
+func TestValue_Get_StructTagDefaults(t *testing.T) {
+	t.Parallel()
+
+	type tls struct {
+		Enabled bool   `default:"true" yaml:"enabled"`
+		Cert    string `yaml:"cert"`
+	}
+
+	type server struct {
+		Host    string        `default:"localhost" yaml:"host"`
+		Port    int           `default:"3301"      yaml:"port"`
+		Timeout time.Duration `default:"5s"        yaml:"timeout"`
+		Peers   []string      `default:"[a, b]"    yaml:"peers"`
+		TLS     tls           `yaml:"tls"`
+		Mode    string        `default:"auto"      yaml:"mode,omitempty"`
+	}
+
+	root := tree.New()
+	root.Set(keypath.NewKeyPath("server"), map[string]any{
+		"port": 3302,
+		"mode": "",
+	})
+
+	val := tree.NewValue(root.Get(keypath.NewKeyPath("server")), keypath.NewKeyPath("server"))
+
+	var dest server
+
+	require.NoError(t, val.Get(&dest))
+	assert.Equal(t, server{
+		Host:    "localhost",
+		Port:    3302,
+		Timeout: 5 * time.Second,
+		Peers:   []string{"a", "b"},
+		TLS:     tls{Enabled: true, Cert: ""},
+		Mode:    "auto",
+	}, dest)
+}
+
+func TestValue_Get_StructTagRequired(t *testing.T) {
+	t.Parallel()
+
+	type tls struct {
+		Cert string `yaml:"cert,required"`
+	}
+
+	type server struct {
+		Host string `yaml:"host,required"`
+		Name string `yaml:"name,required,omitempty"`
+		TLS  tls    `yaml:"tls"`
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		wantPath string
+	}{
+		{
+			name:     "missing key",
+			data:     map[string]any{"name": "a", "tls": map[string]any{"cert": "c"}},
+			wantPath: "server/host",
+		},
+		{
+			name:     "empty value with omitempty",
+			data:     map[string]any{"host": "h", "name": "", "tls": map[string]any{"cert": "c"}},
+			wantPath: "server/name",
+		},
+		{
+			name:     "nested struct",
+			data:     map[string]any{"host": "h", "name": "a"},
+			wantPath: "server/tls/cert",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := tree.New()
+			root.Set(keypath.NewKeyPath("server"), tt.data)
+
+			val := tree.NewValue(root.Get(keypath.NewKeyPath("server")), keypath.NewKeyPath("server"))
+
+			var dest server
+
+			err := val.Get(&dest)
+			require.ErrorIs(t, err, tree.ErrRequiredField)
+
+			var decodeErr *tree.DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			assert.Equal(t, keypath.NewKeyPath(tt.wantPath), decodeErr.Path)
+		})
+	}
+}
+
+func TestValue_Get_StructTagInvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Port int `default:"[unclosed" yaml:"port"`
+	}
+
+	root := tree.New()
+	root.Set(keypath.NewKeyPath("server"), map[string]any{"host": "h"})
+
+	val := tree.NewValue(root.Get(keypath.NewKeyPath("server")), keypath.NewKeyPath("server"))
+
+	var dest server
+
+	err := val.Get(&dest)
+	require.ErrorIs(t, err, tree.ErrParseDefault)
+
+	var decodeErr *tree.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, keypath.NewKeyPath("server/port"), decodeErr.Path)
+}
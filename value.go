@@ -21,6 +21,12 @@ const (
 	EnvSource = meta.EnvSource
 	// ModifiedSource indicates dynamically modified data (e.g., at runtime) for MutableConfig.
 	ModifiedSource = meta.ModifiedSource
+	// FlagSource indicates command-line flags set explicitly.
+	FlagSource = meta.FlagSource
+	// FlagDefaultSource indicates default values of command-line flags.
+	FlagDefaultSource = meta.FlagDefaultSource
+	// HTTPSource indicates a document fetched over HTTP(S).
+	HTTPSource = meta.HTTPSource
 )
 
 // RevisionType defines a revision identifier of configuration, if applicable.